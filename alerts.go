@@ -0,0 +1,457 @@
+package birdactyl
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	AlertPending  = "pending"
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+type AlertRule struct {
+	Name     string
+	Expr     string
+	Resolve  *float64
+	Servers  []string
+	Owners   []string
+	Nodes    []string
+	Severity string
+	Repeat   time.Duration
+	Notify   []AlertNotifier
+}
+
+type Alert struct {
+	Rule       string
+	Severity   string
+	ServerID   string
+	Server     *Server
+	State      string
+	Value      float64
+	Threshold  float64
+	Expr       string
+	Since      time.Time
+	FiredAt    time.Time
+	ResolvedAt time.Time
+}
+
+func (a Alert) Title() string {
+	return fmt.Sprintf("[%s] %s", a.State, a.Rule)
+}
+
+func (a Alert) Message() string {
+	name := a.ServerID
+	if a.Server != nil && a.Server.Name != "" {
+		name = a.Server.Name
+	}
+	return fmt.Sprintf("%s on %s: %s (value %.2f)", a.Rule, name, a.Expr, a.Value)
+}
+
+type AlertNotifier interface {
+	NotifyAlert(api *API, alert Alert) error
+}
+
+type AlertNotifierFunc func(api *API, alert Alert) error
+
+func (f AlertNotifierFunc) NotifyAlert(api *API, alert Alert) error {
+	return f(api, alert)
+}
+
+func OwnerNotifier() AlertNotifier {
+	return AlertNotifierFunc(func(api *API, alert Alert) error {
+		if alert.Server == nil || alert.Server.OwnerID == "" {
+			return fmt.Errorf("no owner known for server %s", alert.ServerID)
+		}
+		notifType := NotifyError
+		if alert.State == AlertResolved {
+			notifType = NotifySuccess
+		}
+		return api.Notify(alert.Server.OwnerID, alert.Title(), alert.Message(), notifType)
+	})
+}
+
+func WebhookNotifier(url string, headers map[string]string) AlertNotifier {
+	return AlertNotifierFunc(func(api *API, alert Alert) error {
+		body, err := json.Marshal(map[string]interface{}{
+			"rule":        alert.Rule,
+			"severity":    alert.Severity,
+			"state":       alert.State,
+			"server_id":   alert.ServerID,
+			"value":       alert.Value,
+			"threshold":   alert.Threshold,
+			"expr":        alert.Expr,
+			"message":     alert.Message(),
+			"since":       alert.Since,
+			"fired_at":    alert.FiredAt,
+			"resolved_at": alert.ResolvedAt,
+		})
+		if err != nil {
+			return err
+		}
+		h := map[string]string{"Content-Type": "application/json"}
+		for k, v := range headers {
+			h[k] = v
+		}
+		resp := api.HTTPPost(url, h, body)
+		if resp.Error != "" {
+			return fmt.Errorf("webhook %s: %s", url, resp.Error)
+		}
+		if resp.Status >= 300 {
+			return fmt.Errorf("webhook %s: status %d", url, resp.Status)
+		}
+		return nil
+	})
+}
+
+type AlertSilence struct {
+	ID       int
+	Rule     string
+	ServerID string
+	Until    time.Time
+}
+
+type alertCondition struct {
+	metric    Metric
+	divisor   Metric
+	op        string
+	threshold float64
+	resolve   float64
+	duration  time.Duration
+}
+
+var alertExprRe = regexp.MustCompile(`^\s*(\w+)\s*(?:/\s*(\w+)\s*)?(>=|<=|==|!=|>|<)\s*([-+]?[0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*(?:for\s+(\S+))?\s*$`)
+
+func parseAlertExpr(expr string) (*alertCondition, error) {
+	m := alertExprRe.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, fmt.Errorf("invalid alert expression %q", expr)
+	}
+	c := &alertCondition{metric: Metric(m[1]), divisor: Metric(m[2]), op: m[3]}
+	if _, ok := (StatsSample{}).Value(c.metric); !ok {
+		return nil, fmt.Errorf("unknown metric %q in %q", m[1], expr)
+	}
+	if c.divisor != "" {
+		if _, ok := (StatsSample{}).Value(c.divisor); !ok {
+			return nil, fmt.Errorf("unknown metric %q in %q", m[2], expr)
+		}
+	}
+	threshold, err := strconv.ParseFloat(m[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold in %q: %w", expr, err)
+	}
+	c.threshold, c.resolve = threshold, threshold
+	if m[5] != "" {
+		if c.duration, err = time.ParseDuration(m[5]); err != nil {
+			return nil, fmt.Errorf("invalid duration in %q: %w", expr, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *alertCondition) value(s StatsSample) (float64, bool) {
+	v, _ := s.Value(c.metric)
+	if c.divisor == "" {
+		return v, true
+	}
+	d, _ := s.Value(c.divisor)
+	if d == 0 {
+		return 0, false
+	}
+	return v / d, true
+}
+
+func (c *alertCondition) holds(v, threshold float64) bool {
+	switch c.op {
+	case ">":
+		return v > threshold
+	case ">=":
+		return v >= threshold
+	case "<":
+		return v < threshold
+	case "<=":
+		return v <= threshold
+	case "==":
+		return v == threshold
+	case "!=":
+		return v != threshold
+	}
+	return false
+}
+
+type alertRule struct {
+	AlertRule
+	cond    *alertCondition
+	servers map[string]bool
+	owners  map[string]bool
+	nodes   map[string]bool
+}
+
+func (r *alertRule) matches(serverID string, server *Server) bool {
+	if len(r.servers) == 0 && len(r.owners) == 0 && len(r.nodes) == 0 {
+		return true
+	}
+	if r.servers[serverID] {
+		return true
+	}
+	if server == nil {
+		return false
+	}
+	return r.owners[server.OwnerID] || r.nodes[server.NodeID]
+}
+
+type alertState struct {
+	Alert
+	notified  time.Time
+	announced bool
+}
+
+type AlertEngine struct {
+	mu        sync.Mutex
+	api       *API
+	async     *AsyncAPI
+	rules     []*alertRule
+	states    map[string]*alertState
+	silences  map[int]AlertSilence
+	nextID    int
+	servers   map[string]*Server
+	refreshed time.Time
+	hooks     []func(Alert)
+	stopped   bool
+}
+
+func NewAlertEngine() *AlertEngine {
+	return &AlertEngine{states: make(map[string]*alertState), silences: make(map[int]AlertSilence), servers: make(map[string]*Server)}
+}
+
+func (p *Plugin) Alert(rule AlertRule) *Plugin {
+	if err := p.alerts.Add(rule); err != nil {
+		return p.setupError(fmt.Errorf("alert %q: %w", rule.Expr, err))
+	}
+	if p.statsOpts == nil {
+		p.statsOpts = &StatsOptions{}
+	}
+	return p
+}
+
+func (p *Plugin) Alerts() *AlertEngine {
+	return p.alerts
+}
+
+func (e *AlertEngine) Add(rule AlertRule) error {
+	cond, err := parseAlertExpr(rule.Expr)
+	if err != nil {
+		return err
+	}
+	if rule.Name == "" {
+		rule.Name = rule.Expr
+	}
+	if rule.Resolve != nil {
+		cond.resolve = *rule.Resolve
+	}
+	r := &alertRule{AlertRule: rule, cond: cond, servers: toSet(rule.Servers), owners: toSet(rule.Owners), nodes: toSet(rule.Nodes)}
+	e.mu.Lock()
+	e.rules = append(e.rules, r)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *AlertEngine) OnAlert(fn func(Alert)) *AlertEngine {
+	e.mu.Lock()
+	e.hooks = append(e.hooks, fn)
+	e.mu.Unlock()
+	return e
+}
+
+func (e *AlertEngine) Attach(api *API, async *AsyncAPI, stats *StatsCollector) {
+	e.mu.Lock()
+	e.api, e.async = api, async
+	e.mu.Unlock()
+	stats.OnSample(e.Evaluate)
+}
+
+func (e *AlertEngine) Stop() {
+	e.mu.Lock()
+	e.api, e.async, e.stopped = nil, nil, true
+	e.mu.Unlock()
+}
+
+func (e *AlertEngine) Silence(rule, serverID string, d time.Duration) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	e.silences[e.nextID] = AlertSilence{ID: e.nextID, Rule: rule, ServerID: serverID, Until: time.Now().Add(d)}
+	return e.nextID
+}
+
+func (e *AlertEngine) Unsilence(id int) {
+	e.mu.Lock()
+	delete(e.silences, id)
+	e.mu.Unlock()
+}
+
+func (e *AlertEngine) Silences() []AlertSilence {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	out := make([]AlertSilence, 0, len(e.silences))
+	for id, s := range e.silences {
+		if now.After(s.Until) {
+			delete(e.silences, id)
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (e *AlertEngine) Active() []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]Alert, 0, len(e.states))
+	for _, st := range e.states {
+		if st.State == AlertPending || st.State == AlertFiring {
+			out = append(out, st.Alert)
+		}
+	}
+	return out
+}
+
+func (e *AlertEngine) silenced(rule, serverID string, at time.Time) bool {
+	for _, s := range e.silences {
+		if (s.Rule == "" || s.Rule == rule) && (s.ServerID == "" || s.ServerID == serverID) && at.Before(s.Until) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *AlertEngine) server(serverID string) *Server {
+	e.mu.Lock()
+	stale := time.Since(e.refreshed) >= time.Minute
+	async := e.async
+	e.mu.Unlock()
+	if stale && async != nil {
+		if servers, err := async.ListServers().Get(); err == nil {
+			byID := make(map[string]*Server, len(servers))
+			for _, s := range servers {
+				byID[s.ID] = s
+			}
+			e.mu.Lock()
+			e.servers, e.refreshed = byID, time.Now()
+			e.mu.Unlock()
+		} else {
+			log.Printf("[alerts] failed to list servers: %v", err)
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.servers[serverID]
+}
+
+func (e *AlertEngine) Evaluate(serverID string, s StatsSample) {
+	e.mu.Lock()
+	stopped := e.stopped
+	e.mu.Unlock()
+	if stopped {
+		return
+	}
+	server := e.server(serverID)
+
+	type pending struct {
+		rule  *alertRule
+		alert Alert
+	}
+	var out []pending
+
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return
+	}
+	for _, r := range e.rules {
+		if !r.matches(serverID, server) {
+			continue
+		}
+		v, ok := r.cond.value(s)
+		if !ok {
+			continue
+		}
+		key := r.Name + "\x00" + serverID
+		st, ok := e.states[key]
+		if !ok {
+			st = &alertState{Alert: Alert{Rule: r.Name, Severity: r.Severity, ServerID: serverID, Expr: r.Expr, Threshold: r.cond.threshold, State: AlertResolved}}
+			e.states[key] = st
+		}
+		st.Server, st.Value = server, v
+
+		notify := false
+		switch st.State {
+		case AlertResolved:
+			if r.cond.holds(v, r.cond.threshold) {
+				st.State, st.Since = AlertPending, s.Time
+				if r.cond.duration == 0 {
+					st.State, st.FiredAt, notify = AlertFiring, s.Time, true
+				}
+			}
+		case AlertPending:
+			if !r.cond.holds(v, r.cond.threshold) {
+				st.State = AlertResolved
+			} else if s.Time.Sub(st.Since) >= r.cond.duration {
+				st.State, st.FiredAt, notify = AlertFiring, s.Time, true
+			}
+		case AlertFiring:
+			if !r.cond.holds(v, r.cond.resolve) {
+				st.State, st.ResolvedAt, notify = AlertResolved, s.Time, true
+			} else if r.Repeat > 0 && s.Time.Sub(st.notified) >= r.Repeat {
+				notify = true
+			}
+		}
+		if notify && st.State == AlertResolved {
+			notify, st.announced = st.announced, false
+		}
+		if notify && !e.silenced(r.Name, serverID, s.Time) {
+			st.notified = s.Time
+			if st.State == AlertFiring {
+				st.announced = true
+			}
+			out = append(out, pending{rule: r, alert: st.Alert})
+		}
+	}
+	api := e.api
+	hooks := e.hooks
+	e.mu.Unlock()
+
+	for _, p := range out {
+		for _, fn := range hooks {
+			fn(p.alert)
+		}
+		if api == nil || len(p.rule.Notify) == 0 {
+			continue
+		}
+		go func(rule *alertRule, alert Alert) {
+			for _, n := range rule.Notify {
+				if err := n.NotifyAlert(api, alert); err != nil {
+					log.Printf("[alerts] %s notification for %s failed: %v", alert.Rule, alert.ServerID, err)
+				}
+			}
+		}(p.rule, p.alert)
+	}
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
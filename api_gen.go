@@ -0,0 +1,655 @@
+// Code generated by internal/gen from proto/plugin.proto. DO NOT EDIT.
+
+package birdactyl
+
+import pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+
+func (a *API) GetServer(id string) (*Server, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.GetServer(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return serverFromProto(r), nil
+}
+
+func (a *API) ListServers() []*Server {
+	req := &pb.ListServersRequest{}
+	r, _ := a.panel.ListServers(a.ctx(), req)
+	return convertAll(r.GetServers(), serverFromProto)
+}
+
+func (a *API) ListServersByUser(userID string) []*Server {
+	req := &pb.ListServersRequest{UserId: userID}
+	r, _ := a.panel.ListServers(a.ctx(), req)
+	return convertAll(r.GetServers(), serverFromProto)
+}
+
+func (a *API) CreateServer(name, userID, nodeID, packageID string, memory, cpu, disk int32) (*Server, error) {
+	req := &pb.CreateServerRequest{Name: name, UserId: userID, NodeId: nodeID, PackageId: packageID, Memory: memory, Cpu: cpu, Disk: disk}
+	r, err := a.panel.CreateServer(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return serverFromProto(r), nil
+}
+
+func (a *API) DeleteServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) UpdateServer(id string, name *string, memory, cpu, disk *int32) (*Server, error) {
+	req := &pb.UpdateServerRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if memory != nil {
+		req.Memory = *memory
+	}
+	if cpu != nil {
+		req.Cpu = *cpu
+	}
+	if disk != nil {
+		req.Disk = *disk
+	}
+	r, err := a.panel.UpdateServer(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return serverFromProto(r), nil
+}
+
+func (a *API) SuspendServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.SuspendServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) UnsuspendServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.UnsuspendServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) StartServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.StartServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) StopServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.StopServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) RestartServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.RestartServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) KillServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.KillServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) ReinstallServer(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.ReinstallServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) TransferServer(serverID, targetNodeID string) error {
+	req := &pb.TransferServerRequest{ServerId: serverID, TargetNodeId: targetNodeID}
+	_, err := a.panel.TransferServer(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetConsoleLog(serverID string, lines int32) ([]string, error) {
+	req := &pb.ConsoleLogRequest{ServerId: serverID, Lines: lines}
+	r, err := a.panel.GetConsoleLog(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetLines(), nil
+}
+
+func (a *API) SendCommand(serverID, command string) error {
+	req := &pb.SendCommandRequest{ServerId: serverID, Command: command}
+	_, err := a.panel.SendCommand(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetFullLog(serverID string) ([]byte, error) {
+	req := &pb.IDRequest{Id: serverID}
+	r, err := a.panel.GetFullLog(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetContent(), nil
+}
+
+func (a *API) SearchLogs(serverID, pattern string, regex bool, limit int32) ([]*LogMatch, error) {
+	req := &pb.SearchLogsRequest{ServerId: serverID, Pattern: pattern, Regex: regex, Limit: limit}
+	r, err := a.panel.SearchLogs(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(r.GetMatches(), logMatchFromProto), nil
+}
+
+func (a *API) ListLogFiles(serverID string) ([]*LogFile, error) {
+	req := &pb.IDRequest{Id: serverID}
+	r, err := a.panel.ListLogFiles(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(r.GetFiles(), logFileFromProto), nil
+}
+
+func (a *API) ReadLogFile(serverID, filename string) ([]byte, error) {
+	req := &pb.ReadLogFileRequest{ServerId: serverID, Filename: filename}
+	r, err := a.panel.ReadLogFile(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetContent(), nil
+}
+
+func (a *API) GetServerStats(serverID string) (*ServerStats, error) {
+	req := &pb.IDRequest{Id: serverID}
+	r, err := a.panel.GetServerStats(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return serverStatsFromProto(r), nil
+}
+
+func (a *API) AddAllocation(serverID string, port int32) error {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	_, err := a.panel.AddAllocation(a.ctx(), req)
+	return err
+}
+
+func (a *API) DeleteAllocation(serverID string, port int32) error {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	_, err := a.panel.DeleteAllocation(a.ctx(), req)
+	return err
+}
+
+func (a *API) SetPrimaryAllocation(serverID string, port int32) error {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	_, err := a.panel.SetPrimaryAllocation(a.ctx(), req)
+	return err
+}
+
+func (a *API) UpdateServerVariables(serverID string, variables map[string]string) error {
+	req := &pb.UpdateVariablesRequest{ServerId: serverID, Variables: variables}
+	_, err := a.panel.UpdateServerVariables(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetUser(id string) (*User, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.GetUser(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return userFromProto(r), nil
+}
+
+func (a *API) GetUserByEmail(email string) (*User, error) {
+	req := &pb.EmailRequest{Email: email}
+	r, err := a.panel.GetUserByEmail(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return userFromProto(r), nil
+}
+
+func (a *API) GetUserByUsername(username string) (*User, error) {
+	req := &pb.UsernameRequest{Username: username}
+	r, err := a.panel.GetUserByUsername(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return userFromProto(r), nil
+}
+
+func (a *API) ListUsers() []*User {
+	req := &pb.ListUsersRequest{}
+	r, _ := a.panel.ListUsers(a.ctx(), req)
+	return convertAll(r.GetUsers(), userFromProto)
+}
+
+func (a *API) CreateUser(email, username, password string) (*User, error) {
+	req := &pb.CreateUserRequest{Email: email, Username: username, Password: password}
+	r, err := a.panel.CreateUser(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return userFromProto(r), nil
+}
+
+func (a *API) DeleteUser(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteUser(a.ctx(), req)
+	return err
+}
+
+func (a *API) UpdateUser(id string, username, email *string) (*User, error) {
+	req := &pb.UpdateUserRequest{Id: id}
+	if username != nil {
+		req.Username = *username
+	}
+	if email != nil {
+		req.Email = *email
+	}
+	r, err := a.panel.UpdateUser(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return userFromProto(r), nil
+}
+
+func (a *API) BanUser(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.BanUser(a.ctx(), req)
+	return err
+}
+
+func (a *API) UnbanUser(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.UnbanUser(a.ctx(), req)
+	return err
+}
+
+func (a *API) SetAdmin(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.SetAdmin(a.ctx(), req)
+	return err
+}
+
+func (a *API) RevokeAdmin(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.RevokeAdmin(a.ctx(), req)
+	return err
+}
+
+func (a *API) SetUserResources(userID string, ramLimit, cpuLimit, diskLimit, serverLimit *int32) error {
+	req := &pb.SetUserResourcesRequest{UserId: userID}
+	if ramLimit != nil {
+		req.RamLimit = *ramLimit
+	}
+	if cpuLimit != nil {
+		req.CpuLimit = *cpuLimit
+	}
+	if diskLimit != nil {
+		req.DiskLimit = *diskLimit
+	}
+	if serverLimit != nil {
+		req.ServerLimit = *serverLimit
+	}
+	_, err := a.panel.SetUserResources(a.ctx(), req)
+	return err
+}
+
+func (a *API) ForcePasswordReset(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.ForcePasswordReset(a.ctx(), req)
+	return err
+}
+
+func (a *API) ListSubusers(serverID string) []*Subuser {
+	req := &pb.IDRequest{Id: serverID}
+	r, _ := a.panel.ListSubusers(a.ctx(), req)
+	return convertAll(r.GetSubusers(), subuserFromProto)
+}
+
+func (a *API) AddSubuser(serverID, email string, permissions []string) (*Subuser, error) {
+	req := &pb.AddSubuserRequest{ServerId: serverID, Email: email, Permissions: permissions}
+	r, err := a.panel.AddSubuser(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return subuserFromProto(r), nil
+}
+
+func (a *API) UpdateSubuser(serverID, subuserID string, permissions []string) error {
+	req := &pb.UpdateSubuserRequest{ServerId: serverID, SubuserId: subuserID, Permissions: permissions}
+	_, err := a.panel.UpdateSubuser(a.ctx(), req)
+	return err
+}
+
+func (a *API) RemoveSubuser(serverID, subuserID string) error {
+	req := &pb.RemoveSubuserRequest{ServerId: serverID, SubuserId: subuserID}
+	_, err := a.panel.RemoveSubuser(a.ctx(), req)
+	return err
+}
+
+func (a *API) ListDatabases(serverID string) []*Database {
+	req := &pb.IDRequest{Id: serverID}
+	r, _ := a.panel.ListDatabases(a.ctx(), req)
+	return convertAll(r.GetDatabases(), databaseFromProto)
+}
+
+func (a *API) CreateDatabase(serverID, name string) (*Database, error) {
+	req := &pb.CreateDatabaseRequest{ServerId: serverID, Name: name}
+	r, err := a.panel.CreateDatabase(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return databaseFromProto(r), nil
+}
+
+func (a *API) DeleteDatabase(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteDatabase(a.ctx(), req)
+	return err
+}
+
+func (a *API) RotateDatabasePassword(id string) (*Database, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.RotateDatabasePassword(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return databaseFromProto(r), nil
+}
+
+func (a *API) ListDatabaseHosts() []*DatabaseHost {
+	req := &pb.Empty{}
+	r, _ := a.panel.ListDatabaseHosts(a.ctx(), req)
+	return convertAll(r.GetHosts(), databaseHostFromProto)
+}
+
+func (a *API) CreateDatabaseHost(name, host string, port int32, username, password string, maxDatabases int32) (*DatabaseHost, error) {
+	req := &pb.CreateDatabaseHostRequest{Name: name, Host: host, Port: port, Username: username, Password: password, MaxDatabases: maxDatabases}
+	r, err := a.panel.CreateDatabaseHost(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return databaseHostFromProto(r), nil
+}
+
+func (a *API) UpdateDatabaseHost(id string, name, host *string, port *int32, username, password *string, maxDatabases *int32) error {
+	req := &pb.UpdateDatabaseHostRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if host != nil {
+		req.Host = *host
+	}
+	if port != nil {
+		req.Port = *port
+	}
+	if username != nil {
+		req.Username = *username
+	}
+	if password != nil {
+		req.Password = *password
+	}
+	if maxDatabases != nil {
+		req.MaxDatabases = *maxDatabases
+	}
+	_, err := a.panel.UpdateDatabaseHost(a.ctx(), req)
+	return err
+}
+
+func (a *API) DeleteDatabaseHost(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteDatabaseHost(a.ctx(), req)
+	return err
+}
+
+func (a *API) ListFiles(serverID, path string) []*File {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	r, _ := a.panel.ListFiles(a.ctx(), req)
+	return convertAll(r.GetFiles(), fileFromProto)
+}
+
+func (a *API) ReadFile(serverID, path string) ([]byte, error) {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	r, err := a.panel.ReadFile(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetContent(), nil
+}
+
+func (a *API) WriteFile(serverID, path string, content []byte) error {
+	req := &pb.WriteFileRequest{ServerId: serverID, Path: path, Content: content}
+	_, err := a.panel.WriteFile(a.ctx(), req)
+	return err
+}
+
+func (a *API) DeleteFile(serverID, path string) error {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	_, err := a.panel.DeleteFile(a.ctx(), req)
+	return err
+}
+
+func (a *API) CreateFolder(serverID, path string) error {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	_, err := a.panel.CreateFolder(a.ctx(), req)
+	return err
+}
+
+func (a *API) MoveFile(serverID, from, to string) error {
+	req := &pb.MoveFileRequest{ServerId: serverID, From: from, To: to}
+	_, err := a.panel.MoveFile(a.ctx(), req)
+	return err
+}
+
+func (a *API) CopyFile(serverID, from, to string) error {
+	req := &pb.MoveFileRequest{ServerId: serverID, From: from, To: to}
+	_, err := a.panel.CopyFile(a.ctx(), req)
+	return err
+}
+
+func (a *API) CompressFiles(serverID string, paths []string, destination string) error {
+	req := &pb.CompressRequest{ServerId: serverID, Paths: paths, Destination: destination}
+	_, err := a.panel.CompressFiles(a.ctx(), req)
+	return err
+}
+
+func (a *API) DecompressFile(serverID, path string) error {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	_, err := a.panel.DecompressFile(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetUploadStatus(serverID, uploadID string) (*UploadStatus, error) {
+	req := &pb.UploadStatusRequest{ServerId: serverID, UploadId: uploadID}
+	r, err := a.panel.GetUploadStatus(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return uploadStatusFromProto(r), nil
+}
+
+func (a *API) ListBackups(serverID string) []*Backup {
+	req := &pb.IDRequest{Id: serverID}
+	r, _ := a.panel.ListBackups(a.ctx(), req)
+	return convertAll(r.GetBackups(), backupFromProto)
+}
+
+func (a *API) CreateBackup(serverID, name string) error {
+	req := &pb.CreateBackupRequest{ServerId: serverID, Name: name}
+	_, err := a.panel.CreateBackup(a.ctx(), req)
+	return err
+}
+
+func (a *API) DeleteBackup(serverID, backupID string) error {
+	req := &pb.DeleteBackupRequest{ServerId: serverID, BackupId: backupID}
+	_, err := a.panel.DeleteBackup(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetBackup(serverID, backupID string) (*Backup, error) {
+	req := &pb.BackupRequest{ServerId: serverID, BackupId: backupID}
+	r, err := a.panel.GetBackup(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return backupFromProto(r), nil
+}
+
+func (a *API) RestoreBackup(serverID, backupID string, truncate bool) error {
+	req := &pb.RestoreBackupRequest{ServerId: serverID, BackupId: backupID, Truncate: truncate}
+	_, err := a.panel.RestoreBackup(a.ctx(), req)
+	return err
+}
+
+func (a *API) ListNodes() []*Node {
+	req := &pb.Empty{}
+	r, _ := a.panel.ListNodes(a.ctx(), req)
+	return convertAll(r.GetNodes(), nodeFromProto)
+}
+
+func (a *API) GetNode(id string) (*Node, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.GetNode(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return nodeFromProto(r), nil
+}
+
+func (a *API) DeleteNode(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteNode(a.ctx(), req)
+	return err
+}
+
+func (a *API) ResetNodeToken(id string) (string, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.ResetNodeToken(a.ctx(), req)
+	if err != nil {
+		return "", err
+	}
+	return r.GetToken(), nil
+}
+
+func (a *API) ListPackages() []*Package {
+	req := &pb.Empty{}
+	r, _ := a.panel.ListPackages(a.ctx(), req)
+	return convertAll(r.GetPackages(), packageFromProto)
+}
+
+func (a *API) GetPackage(id string) (*Package, error) {
+	req := &pb.IDRequest{Id: id}
+	r, err := a.panel.GetPackage(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return packageFromProto(r), nil
+}
+
+func (a *API) CreatePackage(name, description, dockerImage, startupCommand, stopCommand, configFiles string, defaultMemory, defaultCPU, defaultDisk int32, isPublic bool) (*Package, error) {
+	req := &pb.CreatePackageRequest{Name: name, Description: description, DockerImage: dockerImage, StartupCommand: startupCommand, StopCommand: stopCommand, ConfigFiles: configFiles, DefaultMemory: defaultMemory, DefaultCpu: defaultCPU, DefaultDisk: defaultDisk, IsPublic: isPublic}
+	r, err := a.panel.CreatePackage(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return packageFromProto(r), nil
+}
+
+func (a *API) UpdatePackage(id string, name, description *string, defaultMemory, defaultCPU, defaultDisk *int32) (*Package, error) {
+	req := &pb.UpdatePackageRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if description != nil {
+		req.Description = *description
+	}
+	if defaultMemory != nil {
+		req.DefaultMemory = *defaultMemory
+	}
+	if defaultCPU != nil {
+		req.DefaultCpu = *defaultCPU
+	}
+	if defaultDisk != nil {
+		req.DefaultDisk = *defaultDisk
+	}
+	r, err := a.panel.UpdatePackage(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return packageFromProto(r), nil
+}
+
+func (a *API) DeletePackage(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeletePackage(a.ctx(), req)
+	return err
+}
+
+func (a *API) ListIPBans() []*IPBan {
+	req := &pb.Empty{}
+	r, _ := a.panel.ListIPBans(a.ctx(), req)
+	return convertAll(r.GetBans(), ipBanFromProto)
+}
+
+func (a *API) CreateIPBan(ip, reason string) (*IPBan, error) {
+	req := &pb.CreateIPBanRequest{Ip: ip, Reason: reason}
+	r, err := a.panel.CreateIPBan(a.ctx(), req)
+	if err != nil {
+		return nil, err
+	}
+	return ipBanFromProto(r), nil
+}
+
+func (a *API) DeleteIPBan(id string) error {
+	req := &pb.IDRequest{Id: id}
+	_, err := a.panel.DeleteIPBan(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetSettings() *Settings {
+	req := &pb.Empty{}
+	r, _ := a.panel.GetSettings(a.ctx(), req)
+	return settingsFromProto(r)
+}
+
+func (a *API) SetRegistrationEnabled(enabled bool) error {
+	req := &pb.BoolRequest{Value: enabled}
+	_, err := a.panel.SetRegistrationEnabled(a.ctx(), req)
+	return err
+}
+
+func (a *API) SetServerCreationEnabled(enabled bool) error {
+	req := &pb.BoolRequest{Value: enabled}
+	_, err := a.panel.SetServerCreationEnabled(a.ctx(), req)
+	return err
+}
+
+func (a *API) GetActivityLogs(limit int32) []*ActivityLog {
+	req := &pb.GetLogsRequest{Limit: limit}
+	r, _ := a.panel.GetActivityLogs(a.ctx(), req)
+	return convertAll(r.GetLogs(), activityLogFromProto)
+}
+
+func (a *API) Log(level, message string) {
+	req := &pb.LogRequest{Level: level, Message: message}
+	a.panel.Log(a.ctx(), req)
+}
+
+func (a *API) SetKV(key, value string) {
+	req := &pb.KVSetRequest{Key: key, Value: value}
+	a.panel.SetKV(a.ctx(), req)
+}
+
+func (a *API) DeleteKV(key string) {
+	req := &pb.KVRequest{Key: key}
+	a.panel.DeleteKV(a.ctx(), req)
+}
+
+func (a *API) BroadcastEvent(eventType string, data map[string]string) {
+	req := &pb.BroadcastEventRequest{EventType: eventType, Data: data}
+	a.panel.BroadcastEvent(a.ctx(), req)
+}
@@ -0,0 +1,872 @@
+// Code generated by internal/gen from proto/plugin.proto. DO NOT EDIT.
+
+package birdactyl
+
+import (
+	"context"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+func (a *AsyncAPI) GetServer(id string) *Future[*Server] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "GetServer", func(ctx context.Context) (*Server, error) {
+		r, err := a.panel.GetServer(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return serverFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) ListServers() *Future[[]*Server] {
+	req := &pb.ListServersRequest{}
+	return submit(a, "ListServers", func(ctx context.Context) ([]*Server, error) {
+		r, err := a.panel.ListServers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetServers(), serverFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) ListServersByUser(userID string) *Future[[]*Server] {
+	req := &pb.ListServersRequest{UserId: userID}
+	return submit(a, "ListServersByUser", func(ctx context.Context) ([]*Server, error) {
+		r, err := a.panel.ListServers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetServers(), serverFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateServer(name, userID, nodeID, packageID string, memory, cpu, disk int32) *Future[*Server] {
+	req := &pb.CreateServerRequest{Name: name, UserId: userID, NodeId: nodeID, PackageId: packageID, Memory: memory, Cpu: cpu, Disk: disk}
+	return submit(a, "CreateServer", func(ctx context.Context) (*Server, error) {
+		r, err := a.panel.CreateServer(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return serverFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeleteServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) UpdateServer(id string, name *string, memory, cpu, disk *int32) *Future[*Server] {
+	req := &pb.UpdateServerRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if memory != nil {
+		req.Memory = *memory
+	}
+	if cpu != nil {
+		req.Cpu = *cpu
+	}
+	if disk != nil {
+		req.Disk = *disk
+	}
+	return submit(a, "UpdateServer", func(ctx context.Context) (*Server, error) {
+		r, err := a.panel.UpdateServer(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return serverFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) SuspendServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "SuspendServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SuspendServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) UnsuspendServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "UnsuspendServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.UnsuspendServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) StartServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "StartServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.StartServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) StopServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "StopServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.StopServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) RestartServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "RestartServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.RestartServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) KillServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "KillServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.KillServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ReinstallServer(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "ReinstallServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.ReinstallServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) TransferServer(serverID, targetNodeID string) *Future[struct{}] {
+	req := &pb.TransferServerRequest{ServerId: serverID, TargetNodeId: targetNodeID}
+	return submit(a, "TransferServer", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.TransferServer(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetConsoleLog(serverID string, lines int32) *Future[[]string] {
+	req := &pb.ConsoleLogRequest{ServerId: serverID, Lines: lines}
+	return submit(a, "GetConsoleLog", func(ctx context.Context) ([]string, error) {
+		r, err := a.panel.GetConsoleLog(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetLines(), nil
+	})
+}
+
+func (a *AsyncAPI) SendCommand(serverID, command string) *Future[struct{}] {
+	req := &pb.SendCommandRequest{ServerId: serverID, Command: command}
+	return submit(a, "SendCommand", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SendCommand(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetFullLog(serverID string) *Future[[]byte] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "GetFullLog", func(ctx context.Context) ([]byte, error) {
+		r, err := a.panel.GetFullLog(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetContent(), nil
+	})
+}
+
+func (a *AsyncAPI) SearchLogs(serverID, pattern string, regex bool, limit int32) *Future[[]*LogMatch] {
+	req := &pb.SearchLogsRequest{ServerId: serverID, Pattern: pattern, Regex: regex, Limit: limit}
+	return submit(a, "SearchLogs", func(ctx context.Context) ([]*LogMatch, error) {
+		r, err := a.panel.SearchLogs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetMatches(), logMatchFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) ListLogFiles(serverID string) *Future[[]*LogFile] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "ListLogFiles", func(ctx context.Context) ([]*LogFile, error) {
+		r, err := a.panel.ListLogFiles(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetFiles(), logFileFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) ReadLogFile(serverID, filename string) *Future[[]byte] {
+	req := &pb.ReadLogFileRequest{ServerId: serverID, Filename: filename}
+	return submit(a, "ReadLogFile", func(ctx context.Context) ([]byte, error) {
+		r, err := a.panel.ReadLogFile(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetContent(), nil
+	})
+}
+
+func (a *AsyncAPI) GetServerStats(serverID string) *Future[*ServerStats] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "GetServerStats", func(ctx context.Context) (*ServerStats, error) {
+		r, err := a.panel.GetServerStats(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return serverStatsFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) AddAllocation(serverID string, port int32) *Future[struct{}] {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	return submit(a, "AddAllocation", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.AddAllocation(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DeleteAllocation(serverID string, port int32) *Future[struct{}] {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	return submit(a, "DeleteAllocation", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteAllocation(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) SetPrimaryAllocation(serverID string, port int32) *Future[struct{}] {
+	req := &pb.AllocationRequest{ServerId: serverID, Port: port}
+	return submit(a, "SetPrimaryAllocation", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetPrimaryAllocation(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) UpdateServerVariables(serverID string, variables map[string]string) *Future[struct{}] {
+	req := &pb.UpdateVariablesRequest{ServerId: serverID, Variables: variables}
+	return submit(a, "UpdateServerVariables", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.UpdateServerVariables(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetUser(id string) *Future[*User] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "GetUser", func(ctx context.Context) (*User, error) {
+		r, err := a.panel.GetUser(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return userFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) GetUserByEmail(email string) *Future[*User] {
+	req := &pb.EmailRequest{Email: email}
+	return submit(a, "GetUserByEmail", func(ctx context.Context) (*User, error) {
+		r, err := a.panel.GetUserByEmail(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return userFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) GetUserByUsername(username string) *Future[*User] {
+	req := &pb.UsernameRequest{Username: username}
+	return submit(a, "GetUserByUsername", func(ctx context.Context) (*User, error) {
+		r, err := a.panel.GetUserByUsername(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return userFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) ListUsers() *Future[[]*User] {
+	req := &pb.ListUsersRequest{}
+	return submit(a, "ListUsers", func(ctx context.Context) ([]*User, error) {
+		r, err := a.panel.ListUsers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetUsers(), userFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateUser(email, username, password string) *Future[*User] {
+	req := &pb.CreateUserRequest{Email: email, Username: username, Password: password}
+	return submit(a, "CreateUser", func(ctx context.Context) (*User, error) {
+		r, err := a.panel.CreateUser(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return userFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeleteUser(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteUser", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteUser(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) UpdateUser(id string, username, email *string) *Future[*User] {
+	req := &pb.UpdateUserRequest{Id: id}
+	if username != nil {
+		req.Username = *username
+	}
+	if email != nil {
+		req.Email = *email
+	}
+	return submit(a, "UpdateUser", func(ctx context.Context) (*User, error) {
+		r, err := a.panel.UpdateUser(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return userFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) BanUser(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "BanUser", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.BanUser(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) UnbanUser(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "UnbanUser", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.UnbanUser(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) SetAdmin(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "SetAdmin", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetAdmin(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) RevokeAdmin(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "RevokeAdmin", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.RevokeAdmin(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) SetUserResources(userID string, ramLimit, cpuLimit, diskLimit, serverLimit *int32) *Future[struct{}] {
+	req := &pb.SetUserResourcesRequest{UserId: userID}
+	if ramLimit != nil {
+		req.RamLimit = *ramLimit
+	}
+	if cpuLimit != nil {
+		req.CpuLimit = *cpuLimit
+	}
+	if diskLimit != nil {
+		req.DiskLimit = *diskLimit
+	}
+	if serverLimit != nil {
+		req.ServerLimit = *serverLimit
+	}
+	return submit(a, "SetUserResources", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetUserResources(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ForcePasswordReset(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "ForcePasswordReset", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.ForcePasswordReset(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ListSubusers(serverID string) *Future[[]*Subuser] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "ListSubusers", func(ctx context.Context) ([]*Subuser, error) {
+		r, err := a.panel.ListSubusers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetSubusers(), subuserFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) AddSubuser(serverID, email string, permissions []string) *Future[*Subuser] {
+	req := &pb.AddSubuserRequest{ServerId: serverID, Email: email, Permissions: permissions}
+	return submit(a, "AddSubuser", func(ctx context.Context) (*Subuser, error) {
+		r, err := a.panel.AddSubuser(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return subuserFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) UpdateSubuser(serverID, subuserID string, permissions []string) *Future[struct{}] {
+	req := &pb.UpdateSubuserRequest{ServerId: serverID, SubuserId: subuserID, Permissions: permissions}
+	return submit(a, "UpdateSubuser", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.UpdateSubuser(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) RemoveSubuser(serverID, subuserID string) *Future[struct{}] {
+	req := &pb.RemoveSubuserRequest{ServerId: serverID, SubuserId: subuserID}
+	return submit(a, "RemoveSubuser", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.RemoveSubuser(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ListDatabases(serverID string) *Future[[]*Database] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "ListDatabases", func(ctx context.Context) ([]*Database, error) {
+		r, err := a.panel.ListDatabases(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetDatabases(), databaseFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateDatabase(serverID, name string) *Future[*Database] {
+	req := &pb.CreateDatabaseRequest{ServerId: serverID, Name: name}
+	return submit(a, "CreateDatabase", func(ctx context.Context) (*Database, error) {
+		r, err := a.panel.CreateDatabase(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return databaseFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeleteDatabase(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteDatabase", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteDatabase(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) RotateDatabasePassword(id string) *Future[*Database] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "RotateDatabasePassword", func(ctx context.Context) (*Database, error) {
+		r, err := a.panel.RotateDatabasePassword(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return databaseFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) ListDatabaseHosts() *Future[[]*DatabaseHost] {
+	req := &pb.Empty{}
+	return submit(a, "ListDatabaseHosts", func(ctx context.Context) ([]*DatabaseHost, error) {
+		r, err := a.panel.ListDatabaseHosts(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetHosts(), databaseHostFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateDatabaseHost(name, host string, port int32, username, password string, maxDatabases int32) *Future[*DatabaseHost] {
+	req := &pb.CreateDatabaseHostRequest{Name: name, Host: host, Port: port, Username: username, Password: password, MaxDatabases: maxDatabases}
+	return submit(a, "CreateDatabaseHost", func(ctx context.Context) (*DatabaseHost, error) {
+		r, err := a.panel.CreateDatabaseHost(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return databaseHostFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) UpdateDatabaseHost(id string, name, host *string, port *int32, username, password *string, maxDatabases *int32) *Future[struct{}] {
+	req := &pb.UpdateDatabaseHostRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if host != nil {
+		req.Host = *host
+	}
+	if port != nil {
+		req.Port = *port
+	}
+	if username != nil {
+		req.Username = *username
+	}
+	if password != nil {
+		req.Password = *password
+	}
+	if maxDatabases != nil {
+		req.MaxDatabases = *maxDatabases
+	}
+	return submit(a, "UpdateDatabaseHost", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.UpdateDatabaseHost(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DeleteDatabaseHost(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteDatabaseHost", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteDatabaseHost(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ListFiles(serverID, path string) *Future[[]*File] {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	return submit(a, "ListFiles", func(ctx context.Context) ([]*File, error) {
+		r, err := a.panel.ListFiles(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetFiles(), fileFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) ReadFile(serverID, path string) *Future[[]byte] {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	return submit(a, "ReadFile", func(ctx context.Context) ([]byte, error) {
+		r, err := a.panel.ReadFile(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return r.GetContent(), nil
+	})
+}
+
+func (a *AsyncAPI) WriteFile(serverID, path string, content []byte) *Future[struct{}] {
+	req := &pb.WriteFileRequest{ServerId: serverID, Path: path, Content: content}
+	return submit(a, "WriteFile", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.WriteFile(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DeleteFile(serverID, path string) *Future[struct{}] {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	return submit(a, "DeleteFile", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteFile(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) CreateFolder(serverID, path string) *Future[struct{}] {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	return submit(a, "CreateFolder", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.CreateFolder(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) MoveFile(serverID, from, to string) *Future[struct{}] {
+	req := &pb.MoveFileRequest{ServerId: serverID, From: from, To: to}
+	return submit(a, "MoveFile", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.MoveFile(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) CopyFile(serverID, from, to string) *Future[struct{}] {
+	req := &pb.MoveFileRequest{ServerId: serverID, From: from, To: to}
+	return submit(a, "CopyFile", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.CopyFile(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) CompressFiles(serverID string, paths []string, destination string) *Future[struct{}] {
+	req := &pb.CompressRequest{ServerId: serverID, Paths: paths, Destination: destination}
+	return submit(a, "CompressFiles", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.CompressFiles(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DecompressFile(serverID, path string) *Future[struct{}] {
+	req := &pb.FilePathRequest{ServerId: serverID, Path: path}
+	return submit(a, "DecompressFile", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DecompressFile(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetUploadStatus(serverID, uploadID string) *Future[*UploadStatus] {
+	req := &pb.UploadStatusRequest{ServerId: serverID, UploadId: uploadID}
+	return submit(a, "GetUploadStatus", func(ctx context.Context) (*UploadStatus, error) {
+		r, err := a.panel.GetUploadStatus(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return uploadStatusFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) ListBackups(serverID string) *Future[[]*Backup] {
+	req := &pb.IDRequest{Id: serverID}
+	return submit(a, "ListBackups", func(ctx context.Context) ([]*Backup, error) {
+		r, err := a.panel.ListBackups(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetBackups(), backupFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateBackup(serverID, name string) *Future[struct{}] {
+	req := &pb.CreateBackupRequest{ServerId: serverID, Name: name}
+	return submit(a, "CreateBackup", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.CreateBackup(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DeleteBackup(serverID, backupID string) *Future[struct{}] {
+	req := &pb.DeleteBackupRequest{ServerId: serverID, BackupId: backupID}
+	return submit(a, "DeleteBackup", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteBackup(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetBackup(serverID, backupID string) *Future[*Backup] {
+	req := &pb.BackupRequest{ServerId: serverID, BackupId: backupID}
+	return submit(a, "GetBackup", func(ctx context.Context) (*Backup, error) {
+		r, err := a.panel.GetBackup(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return backupFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) RestoreBackup(serverID, backupID string, truncate bool) *Future[struct{}] {
+	req := &pb.RestoreBackupRequest{ServerId: serverID, BackupId: backupID, Truncate: truncate}
+	return submit(a, "RestoreBackup", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.RestoreBackup(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ListNodes() *Future[[]*Node] {
+	req := &pb.Empty{}
+	return submit(a, "ListNodes", func(ctx context.Context) ([]*Node, error) {
+		r, err := a.panel.ListNodes(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetNodes(), nodeFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) GetNode(id string) *Future[*Node] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "GetNode", func(ctx context.Context) (*Node, error) {
+		r, err := a.panel.GetNode(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return nodeFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeleteNode(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteNode", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteNode(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ResetNodeToken(id string) *Future[string] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "ResetNodeToken", func(ctx context.Context) (string, error) {
+		r, err := a.panel.ResetNodeToken(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return r.GetToken(), nil
+	})
+}
+
+func (a *AsyncAPI) ListPackages() *Future[[]*Package] {
+	req := &pb.Empty{}
+	return submit(a, "ListPackages", func(ctx context.Context) ([]*Package, error) {
+		r, err := a.panel.ListPackages(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetPackages(), packageFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) GetPackage(id string) *Future[*Package] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "GetPackage", func(ctx context.Context) (*Package, error) {
+		r, err := a.panel.GetPackage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return packageFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) CreatePackage(name, description, dockerImage, startupCommand, stopCommand, configFiles string, defaultMemory, defaultCPU, defaultDisk int32, isPublic bool) *Future[*Package] {
+	req := &pb.CreatePackageRequest{Name: name, Description: description, DockerImage: dockerImage, StartupCommand: startupCommand, StopCommand: stopCommand, ConfigFiles: configFiles, DefaultMemory: defaultMemory, DefaultCpu: defaultCPU, DefaultDisk: defaultDisk, IsPublic: isPublic}
+	return submit(a, "CreatePackage", func(ctx context.Context) (*Package, error) {
+		r, err := a.panel.CreatePackage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return packageFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) UpdatePackage(id string, name, description *string, defaultMemory, defaultCPU, defaultDisk *int32) *Future[*Package] {
+	req := &pb.UpdatePackageRequest{Id: id}
+	if name != nil {
+		req.Name = *name
+	}
+	if description != nil {
+		req.Description = *description
+	}
+	if defaultMemory != nil {
+		req.DefaultMemory = *defaultMemory
+	}
+	if defaultCPU != nil {
+		req.DefaultCpu = *defaultCPU
+	}
+	if defaultDisk != nil {
+		req.DefaultDisk = *defaultDisk
+	}
+	return submit(a, "UpdatePackage", func(ctx context.Context) (*Package, error) {
+		r, err := a.panel.UpdatePackage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return packageFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeletePackage(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeletePackage", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeletePackage(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) ListIPBans() *Future[[]*IPBan] {
+	req := &pb.Empty{}
+	return submit(a, "ListIPBans", func(ctx context.Context) ([]*IPBan, error) {
+		r, err := a.panel.ListIPBans(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetBans(), ipBanFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) CreateIPBan(ip, reason string) *Future[*IPBan] {
+	req := &pb.CreateIPBanRequest{Ip: ip, Reason: reason}
+	return submit(a, "CreateIPBan", func(ctx context.Context) (*IPBan, error) {
+		r, err := a.panel.CreateIPBan(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return ipBanFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) DeleteIPBan(id string) *Future[struct{}] {
+	req := &pb.IDRequest{Id: id}
+	return submit(a, "DeleteIPBan", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteIPBan(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetSettings() *Future[*Settings] {
+	req := &pb.Empty{}
+	return submit(a, "GetSettings", func(ctx context.Context) (*Settings, error) {
+		r, err := a.panel.GetSettings(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return settingsFromProto(r), nil
+	})
+}
+
+func (a *AsyncAPI) SetRegistrationEnabled(enabled bool) *Future[struct{}] {
+	req := &pb.BoolRequest{Value: enabled}
+	return submit(a, "SetRegistrationEnabled", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetRegistrationEnabled(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) SetServerCreationEnabled(enabled bool) *Future[struct{}] {
+	req := &pb.BoolRequest{Value: enabled}
+	return submit(a, "SetServerCreationEnabled", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetServerCreationEnabled(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) GetActivityLogs(limit int32) *Future[[]*ActivityLog] {
+	req := &pb.GetLogsRequest{Limit: limit}
+	return submit(a, "GetActivityLogs", func(ctx context.Context) ([]*ActivityLog, error) {
+		r, err := a.panel.GetActivityLogs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return convertAll(r.GetLogs(), activityLogFromProto), nil
+	})
+}
+
+func (a *AsyncAPI) Log(level, message string) *Future[struct{}] {
+	req := &pb.LogRequest{Level: level, Message: message}
+	return submit(a, "Log", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.Log(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) SetKV(key, value string) *Future[struct{}] {
+	req := &pb.KVSetRequest{Key: key, Value: value}
+	return submit(a, "SetKV", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.SetKV(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) DeleteKV(key string) *Future[struct{}] {
+	req := &pb.KVRequest{Key: key}
+	return submit(a, "DeleteKV", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.DeleteKV(ctx, req)
+		return struct{}{}, err
+	})
+}
+
+func (a *AsyncAPI) BroadcastEvent(eventType string, data map[string]string) *Future[struct{}] {
+	req := &pb.BroadcastEventRequest{EventType: eventType, Data: data}
+	return submit(a, "BroadcastEvent", func(ctx context.Context) (struct{}, error) {
+		_, err := a.panel.BroadcastEvent(ctx, req)
+		return struct{}{}, err
+	})
+}
@@ -0,0 +1,46 @@
+package birdactyl
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := replaceFileAtomic(path, data, perm)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func replaceFileAtomic(path string, data []byte, perm os.FileMode) (*os.File, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return nil, err
+	}
+	fail := func(err error) (*os.File, error) {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fail(err)
+	}
+	f, err := os.OpenFile(tmp.Name(), os.O_WRONLY|os.O_APPEND, perm)
+	if err != nil {
+		return fail(err)
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		f.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return f, nil
+}
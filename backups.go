@@ -0,0 +1,439 @@
+package birdactyl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrBackupRunning = errors.New("birdactyl: backup policy is already running")
+
+type BackupRetention struct {
+	Last   int
+	Hourly int
+	Daily  int
+	Weekly int
+}
+
+func (r BackupRetention) empty() bool {
+	return r.Last <= 0 && r.Hourly <= 0 && r.Daily <= 0 && r.Weekly <= 0
+}
+
+type BackupHook func(api *API, server *Server) error
+
+type BackupPolicy struct {
+	Name         string
+	Cron         string
+	Servers      []string
+	Packages     []string
+	Retention    BackupRetention
+	Before       []BackupHook
+	After        []BackupHook
+	Concurrency  int
+	SkipOffline  bool
+	NotifyUsers  []string
+	NotifyAdmins bool
+	OnReport     func(BackupReport)
+}
+
+type BackupResult struct {
+	ServerID   string
+	ServerName string
+	Backup     string
+	Pruned     []string
+	Skipped    bool
+	Err        error
+}
+
+type BackupReport struct {
+	Policy   string
+	Started  time.Time
+	Finished time.Time
+	Results  []BackupResult
+	Err      error
+}
+
+func (r BackupReport) Failed() []BackupResult {
+	var out []BackupResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+func (r BackupReport) Title() string {
+	if r.Err != nil {
+		return fmt.Sprintf("Backup policy %s failed", r.Policy)
+	}
+	if failed := len(r.Failed()); failed > 0 {
+		return fmt.Sprintf("Backup policy %s: %d of %d failed", r.Policy, failed, len(r.Results))
+	}
+	return fmt.Sprintf("Backup policy %s completed", r.Policy)
+}
+
+func (r BackupReport) Message() string {
+	var b strings.Builder
+	created, pruned, skipped := 0, 0, 0
+	for _, res := range r.Results {
+		switch {
+		case res.Skipped:
+			skipped++
+		case res.Backup != "" && res.Err == nil:
+			created++
+		}
+		pruned += len(res.Pruned)
+	}
+	fmt.Fprintf(&b, "%d backups created, %d pruned, %d skipped in %s.", created, pruned, skipped, r.Finished.Sub(r.Started).Round(time.Second))
+	if r.Err != nil {
+		fmt.Fprintf(&b, "\n%v", r.Err)
+	}
+	for _, res := range r.Failed() {
+		name := res.ServerName
+		if name == "" {
+			name = res.ServerID
+		}
+		fmt.Fprintf(&b, "\n%s: %v", name, res.Err)
+	}
+	return b.String()
+}
+
+func CommandHook(command string, opts ExecOptions) BackupHook {
+	return func(api *API, server *Server) error {
+		stats, err := api.GetServerStats(server.ID)
+		if err != nil {
+			return err
+		}
+		if stats.State != ServerStateRunning {
+			return nil
+		}
+		_, err = api.Exec(server.ID, command, opts)
+		return err
+	}
+}
+
+func SaveAllHook(timeout time.Duration) BackupHook {
+	return CommandHook("save-all flush", ExecOptions{Until: `Saved the game|All dimensions are saved`, Timeout: timeout})
+}
+
+func SaveOffHook() BackupHook {
+	return CommandHook("save-off", ExecOptions{Until: `(?i)automatic saving is now disabled|saving is already turned off`, Timeout: 10 * time.Second})
+}
+
+func SaveOnHook() BackupHook {
+	return CommandHook("save-on", ExecOptions{Until: `(?i)automatic saving is now enabled|saving is already turned on`, Timeout: 10 * time.Second})
+}
+
+func DelayHook(d time.Duration) BackupHook {
+	return func(api *API, _ *Server) error {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return nil
+		case <-api.ctx().Done():
+			return api.ctx().Err()
+		}
+	}
+}
+
+type BackupManager struct {
+	mu       sync.Mutex
+	api      *API
+	ctx      context.Context
+	cancel   context.CancelFunc
+	policies map[string]*BackupPolicy
+	running  map[string]bool
+	reports  map[string]BackupReport
+}
+
+func newBackupManager() *BackupManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackupManager{
+		ctx:      ctx,
+		cancel:   cancel,
+		policies: make(map[string]*BackupPolicy),
+		running:  make(map[string]bool),
+		reports:  make(map[string]BackupReport),
+	}
+}
+
+func (p *Plugin) BackupPolicy(policy BackupPolicy) *Plugin {
+	if policy.Name == "" || policy.Cron == "" || strings.Contains(policy.Name, ":") {
+		return p.setupError(fmt.Errorf("backup policy %q needs a name without colons and a cron schedule", policy.Name))
+	}
+	p.backups.mu.Lock()
+	p.backups.policies[policy.Name] = &policy
+	p.backups.mu.Unlock()
+	return p.Schedule("backup-"+policy.Name, policy.Cron, func() {
+		if _, err := p.backups.Run(policy.Name); err != nil {
+			log.Printf("[backups] policy %s: %v", policy.Name, err)
+		}
+	})
+}
+
+func (p *Plugin) Backups() *BackupManager {
+	return p.backups
+}
+
+func (m *BackupManager) Policies() []BackupPolicy {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]BackupPolicy, 0, len(m.policies))
+	for _, p := range m.policies {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (m *BackupManager) LastReport(policy string) (BackupReport, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.reports[policy]
+	return r, ok
+}
+
+func (m *BackupManager) policy(name string) (*BackupPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.policies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backup policy %q", name)
+	}
+	return p, nil
+}
+
+func (m *BackupManager) Run(name string) (BackupReport, error) {
+	policy, err := m.policy(name)
+	if err != nil {
+		return BackupReport{}, err
+	}
+	m.mu.Lock()
+	if m.running[name] {
+		m.mu.Unlock()
+		return BackupReport{}, ErrBackupRunning
+	}
+	m.running[name] = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, name)
+		m.mu.Unlock()
+	}()
+
+	report := BackupReport{Policy: name, Started: time.Now()}
+	targets, err := m.targets(policy)
+	if err != nil {
+		report.Err = fmt.Errorf("list servers: %w", err)
+	}
+	var mu sync.Mutex
+	ForEach(m.ctx, targets, policy.Concurrency, func(ctx context.Context, s *Server) error {
+		res := m.backup(ctx, policy, s, report.Started)
+		mu.Lock()
+		report.Results = append(report.Results, res)
+		mu.Unlock()
+		return nil
+	})
+	sort.Slice(report.Results, func(i, j int) bool { return report.Results[i].ServerID < report.Results[j].ServerID })
+	report.Finished = time.Now()
+
+	m.mu.Lock()
+	m.reports[name] = report
+	m.mu.Unlock()
+	m.report(policy, report)
+	return report, report.Err
+}
+
+func (m *BackupManager) RunServer(name, serverID string) (BackupResult, error) {
+	policy, err := m.policy(name)
+	if err != nil {
+		return BackupResult{}, err
+	}
+	server, err := m.api.GetServer(serverID)
+	if err != nil {
+		return BackupResult{}, err
+	}
+	res := m.backup(m.ctx, policy, server, time.Now())
+	return res, res.Err
+}
+
+func (m *BackupManager) targets(policy *BackupPolicy) ([]*Server, error) {
+	servers, err := m.api.listServers()
+	if err != nil || (len(policy.Servers) == 0 && len(policy.Packages) == 0) {
+		return servers, err
+	}
+	ids, packages := toSet(policy.Servers), toSet(policy.Packages)
+	var out []*Server
+	for _, s := range servers {
+		if ids[s.ID] || packages[s.PackageID] {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *BackupManager) backup(ctx context.Context, policy *BackupPolicy, s *Server, at time.Time) BackupResult {
+	res := BackupResult{ServerID: s.ID, ServerName: s.Name}
+	if s.Suspended {
+		res.Skipped = true
+		return res
+	}
+	api := m.api.WithContext(ctx)
+	if policy.SkipOffline {
+		stats, err := api.GetServerStats(s.ID)
+		if err != nil {
+			res.Err = fmt.Errorf("server state: %w", err)
+			return res
+		}
+		if stats.State != ServerStateRunning {
+			res.Skipped = true
+			return res
+		}
+	}
+
+	for _, hook := range policy.Before {
+		if res.Err = hook(api, s); res.Err != nil {
+			res.Err = fmt.Errorf("pre-backup hook: %w", res.Err)
+			break
+		}
+	}
+	if res.Err == nil {
+		res.Backup = policy.Name + "-" + at.UTC().Format("20060102-150405")
+		if err := api.CreateBackup(s.ID, res.Backup); err != nil {
+			res.Err = fmt.Errorf("create backup: %w", err)
+		}
+	}
+	for _, hook := range policy.After {
+		if err := hook(m.api, s); err != nil && res.Err == nil {
+			res.Err = fmt.Errorf("post-backup hook: %w", err)
+		}
+	}
+	if res.Err != nil || policy.Retention.empty() {
+		return res
+	}
+
+	pruned, err := m.prune(policy, s.ID)
+	res.Pruned = pruned
+	if err != nil {
+		res.Err = fmt.Errorf("prune: %w", err)
+	}
+	return res
+}
+
+func (m *BackupManager) stop() {
+	m.cancel()
+}
+
+func (m *BackupManager) Prune(name, serverID string) ([]string, error) {
+	policy, err := m.policy(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.prune(policy, serverID)
+}
+
+func (m *BackupManager) prune(policy *BackupPolicy, serverID string) ([]string, error) {
+	backups, err := m.api.listBackups(serverID)
+	if err != nil {
+		return nil, err
+	}
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(policy.Name) + `-\d{8}-\d{6}$`)
+	var owned []*Backup
+	for _, b := range backups {
+		if pattern.MatchString(b.Name) {
+			owned = append(owned, b)
+		}
+	}
+	_, remove := ApplyRetention(owned, policy.Retention)
+	var pruned []string
+	for _, b := range remove {
+		if err := m.api.DeleteBackup(serverID, b.ID); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, b.ID)
+	}
+	return pruned, nil
+}
+
+func (m *BackupManager) report(policy *BackupPolicy, report BackupReport) {
+	if policy.OnReport != nil {
+		policy.OnReport(report)
+	}
+	notifType := NotifySuccess
+	if len(report.Failed()) > 0 {
+		notifType = NotifyError
+	}
+	if len(policy.NotifyUsers) > 0 {
+		if err := m.api.NotifyUsers(policy.NotifyUsers, report.Title(), report.Message(), notifType); err != nil {
+			log.Printf("[backups] failed to notify users for %s: %v", policy.Name, err)
+		}
+	}
+	if policy.NotifyAdmins {
+		if err := m.api.NotifyAdmins(report.Title(), report.Message(), notifType); err != nil {
+			log.Printf("[backups] failed to notify admins for %s: %v", policy.Name, err)
+		}
+	}
+}
+
+func ApplyRetention(backups []*Backup, r BackupRetention) (keep, remove []*Backup) {
+	type dated struct {
+		b  *Backup
+		at time.Time
+	}
+	var sorted []dated
+	for _, b := range backups {
+		at, err := parseColumnTime(b.CreatedAt)
+		if err != nil {
+			keep = append(keep, b)
+			continue
+		}
+		sorted = append(sorted, dated{b, at})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].at.After(sorted[j].at) })
+
+	kept := make(map[*Backup]bool)
+	for i := 0; i < r.Last && i < len(sorted); i++ {
+		kept[sorted[i].b] = true
+	}
+	buckets := []struct {
+		n   int
+		key func(time.Time) string
+	}{
+		{r.Hourly, func(t time.Time) string { return t.UTC().Format("2006-01-02T15") }},
+		{r.Daily, func(t time.Time) string { return t.UTC().Format("2006-01-02") }},
+		{r.Weekly, func(t time.Time) string {
+			y, w := t.UTC().ISOWeek()
+			return fmt.Sprintf("%d-W%02d", y, w)
+		}},
+	}
+	for _, bucket := range buckets {
+		seen := make(map[string]bool)
+		for _, d := range sorted {
+			if len(seen) >= bucket.n {
+				break
+			}
+			k := bucket.key(d.at)
+			if !seen[k] {
+				seen[k] = true
+				kept[d.b] = true
+			}
+		}
+	}
+
+	for _, d := range sorted {
+		if kept[d.b] {
+			keep = append(keep, d.b)
+		} else {
+			remove = append(remove, d.b)
+		}
+	}
+	return keep, remove
+}
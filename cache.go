@@ -0,0 +1,340 @@
+package birdactyl
+
+import (
+	"container/list"
+	"errors"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNotStarted = errors.New("birdactyl: plugin has not started")
+
+type CacheOptions struct {
+	TTL        time.Duration
+	MaxEntries int
+}
+
+var DefaultCacheOptions = CacheOptions{TTL: 30 * time.Second, MaxEntries: 1000}
+
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Entries   int
+}
+
+type cache[T any] struct {
+	mu      sync.Mutex
+	opts    CacheOptions
+	clone   func(T) T
+	entries map[string]*list.Element
+	lru     *list.List
+	calls   map[string]*cacheCall[T]
+	stats   CacheStats
+}
+
+type cacheEntry[T any] struct {
+	key     string
+	value   T
+	expires time.Time
+}
+
+type cacheCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+	stale bool
+}
+
+func newCache[T any](opts CacheOptions, clone func(T) T) *cache[T] {
+	return &cache[T]{opts: opts, clone: clone, entries: make(map[string]*list.Element), lru: list.New(), calls: make(map[string]*cacheCall[T])}
+}
+
+func (c *cache[T]) get(key string, load func() (T, error)) (T, error) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry[T])
+		if time.Now().Before(e.expires) {
+			c.lru.MoveToFront(el)
+			c.stats.Hits++
+			c.mu.Unlock()
+			return c.clone(e.value), nil
+		}
+		c.remove(el)
+	}
+	c.stats.Misses++
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		if call.err != nil {
+			return call.value, call.err
+		}
+		return c.clone(call.value), nil
+	}
+	call := &cacheCall[T]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = load()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err == nil && !call.stale {
+		c.put(key, call.value)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	if call.err != nil {
+		return call.value, call.err
+	}
+	return c.clone(call.value), nil
+}
+
+func (c *cache[T]) put(key string, value T) {
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry[T]{key: key, value: value, expires: time.Now().Add(c.opts.TTL)})
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *cache[T]) contains(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	return ok && time.Now().Before(el.Value.(*cacheEntry[T]).expires)
+}
+
+func (c *cache[T]) set(key string, value T) {
+	c.mu.Lock()
+	c.put(key, value)
+	c.mu.Unlock()
+}
+
+func (c *cache[T]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry[T]).key)
+}
+
+func (c *cache[T]) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	if call, ok := c.calls[key]; ok {
+		call.stale = true
+	}
+}
+
+func (c *cache[T]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	for _, call := range c.calls {
+		call.stale = true
+	}
+}
+
+func (c *cache[T]) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.lru.Len()
+	return s
+}
+
+var cacheInvalidationEvents = []string{
+	"server.update", "server.delete", "server.suspend", "server.unsuspend", "server.transfer", "server.reinstall",
+	"user.update", "user.delete", "user.ban", "user.unban",
+	"node.update", "node.delete",
+	"package.update", "package.delete",
+}
+
+type CachedAPI struct {
+	mu       sync.RWMutex
+	api      *API
+	servers  *cache[*Server]
+	users    *cache[*User]
+	nodes    *cache[*Node]
+	packages *cache[*Package]
+}
+
+func newCachedAPI(opts CacheOptions) *CachedAPI {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheOptions.TTL
+	}
+	return &CachedAPI{
+		servers:  newCache(opts, cloneServer),
+		users:    newCache(opts, cloneUser),
+		nodes:    newCache(opts, cloneNode),
+		packages: newCache(opts, clonePackage),
+	}
+}
+
+func cloneServer(s *Server) *Server {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func cloneUser(u *User) *User {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+func cloneNode(n *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.Labels = maps.Clone(n.Labels)
+	return &c
+}
+
+func clonePackage(p *Package) *Package {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+func (p *Plugin) UseCache(opts CacheOptions) *Plugin {
+	p.cache = newCachedAPI(opts)
+	p.bus.declare(cacheInvalidationEvents...)
+	p.bus.subscribe("*", p.cache.handleEvent)
+	if p.api != nil {
+		p.cache.attach(p.api)
+	}
+	return p
+}
+
+func (c *CachedAPI) attach(api *API) {
+	c.mu.Lock()
+	c.api = api
+	c.mu.Unlock()
+}
+
+func (c *CachedAPI) client() *API {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.api
+}
+
+func (p *Plugin) Cache() *CachedAPI {
+	return p.cache
+}
+
+func (c *CachedAPI) GetServer(id string) (*Server, error) {
+	api := c.client()
+	if api == nil {
+		return nil, ErrNotStarted
+	}
+	return c.servers.get(id, func() (*Server, error) { return api.GetServer(id) })
+}
+
+func (c *CachedAPI) GetUser(id string) (*User, error) {
+	api := c.client()
+	if api == nil {
+		return nil, ErrNotStarted
+	}
+	return c.users.get(id, func() (*User, error) { return api.GetUser(id) })
+}
+
+func (c *CachedAPI) GetNode(id string) (*Node, error) {
+	api := c.client()
+	if api == nil {
+		return nil, ErrNotStarted
+	}
+	return c.nodes.get(id, func() (*Node, error) { return api.GetNode(id) })
+}
+
+func (c *CachedAPI) GetPackage(id string) (*Package, error) {
+	api := c.client()
+	if api == nil {
+		return nil, ErrNotStarted
+	}
+	return c.packages.get(id, func() (*Package, error) { return api.GetPackage(id) })
+}
+
+func (c *CachedAPI) InvalidateServer(id string) {
+	c.servers.invalidate(id)
+}
+
+func (c *CachedAPI) InvalidateUser(id string) {
+	c.users.invalidate(id)
+}
+
+func (c *CachedAPI) InvalidateNode(id string) {
+	c.nodes.invalidate(id)
+}
+
+func (c *CachedAPI) InvalidatePackage(id string) {
+	c.packages.invalidate(id)
+}
+
+func (c *CachedAPI) Clear() {
+	c.servers.clear()
+	c.users.clear()
+	c.nodes.clear()
+	c.packages.clear()
+}
+
+func (c *CachedAPI) Stats() map[string]CacheStats {
+	return map[string]CacheStats{
+		"servers":  c.servers.snapshot(),
+		"users":    c.users.snapshot(),
+		"nodes":    c.nodes.snapshot(),
+		"packages": c.packages.snapshot(),
+	}
+}
+
+func (c *CachedAPI) handleEvent(ev Event) {
+	kind, _, ok := strings.Cut(ev.Type, ".")
+	if !ok {
+		return
+	}
+	id := ev.Data[kind+"_id"]
+	if id == "" {
+		id = ev.Data["id"]
+	}
+
+	switch kind {
+	case "server":
+		if id == "" {
+			c.servers.clear()
+		} else {
+			c.servers.invalidate(id)
+		}
+	case "user":
+		if id == "" {
+			c.users.clear()
+		} else {
+			c.users.invalidate(id)
+		}
+	case "node":
+		if id == "" {
+			c.nodes.clear()
+		} else {
+			c.nodes.invalidate(id)
+		}
+	case "package":
+		if id == "" {
+			c.packages.clear()
+		} else {
+			c.packages.invalidate(id)
+		}
+	}
+}
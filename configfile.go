@@ -0,0 +1,703 @@
+package birdactyl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrConfigConflict = errors.New("birdactyl: config file changed since it was read")
+	ErrConfigDetached = errors.New("birdactyl: config file is not attached to a server")
+)
+
+type ConfigFormat string
+
+const (
+	ConfigProperties ConfigFormat = "properties"
+	ConfigINI        ConfigFormat = "ini"
+	ConfigYAML       ConfigFormat = "yaml"
+	ConfigJSON       ConfigFormat = "json"
+	ConfigTOML       ConfigFormat = "toml"
+)
+
+var ConfigEditRetries = 3
+
+func ConfigFormatOf(name string) ConfigFormat {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yml", ".yaml":
+		return ConfigYAML
+	case ".json", ".json5", ".mcmeta":
+		return ConfigJSON
+	case ".toml":
+		return ConfigTOML
+	case ".ini", ".cfg", ".conf":
+		return ConfigINI
+	default:
+		return ConfigProperties
+	}
+}
+
+type configDoc interface {
+	get(key string) (interface{}, bool)
+	set(key string, value interface{}) error
+	remove(key string) bool
+	keys() []string
+	encode() ([]byte, error)
+}
+
+type ConfigFile struct {
+	api      *API
+	serverID string
+	path     string
+	format   ConfigFormat
+	sum      string
+	original []byte
+	doc      configDoc
+}
+
+func ParseConfig(format ConfigFormat, data []byte) (*ConfigFile, error) {
+	var doc configDoc
+	var err error
+	switch format {
+	case ConfigProperties:
+		doc = parseLineConfig(propertiesDialect, data)
+	case ConfigINI:
+		doc = parseLineConfig(iniDialect, data)
+	case ConfigTOML:
+		doc = parseLineConfig(tomlDialect, data)
+	case ConfigYAML:
+		doc, err = parseYAMLConfig(data)
+	case ConfigJSON:
+		doc, err = parseJSONConfig(data)
+	default:
+		return nil, fmt.Errorf("unknown config format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s config: %w", format, err)
+	}
+	return &ConfigFile{format: format, sum: configSum(data), original: data, doc: doc}, nil
+}
+
+func (a *API) OpenConfig(serverID, path string) (*ConfigFile, error) {
+	return a.OpenConfigAs(serverID, path, ConfigFormatOf(path))
+}
+
+func (a *API) OpenConfigAs(serverID, path string, format ConfigFormat) (*ConfigFile, error) {
+	data, err := a.ReadFile(serverID, path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseConfig(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	c.api, c.serverID, c.path = a, serverID, path
+	return c, nil
+}
+
+func (a *API) EditConfig(serverID, path string, fn func(c *ConfigFile) error) error {
+	for attempt := 0; ; attempt++ {
+		c, err := a.OpenConfig(serverID, path)
+		if err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+		err = c.Save()
+		if !errors.Is(err, ErrConfigConflict) || attempt >= ConfigEditRetries {
+			return err
+		}
+	}
+}
+
+func configSum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *ConfigFile) Format() ConfigFormat {
+	return c.format
+}
+
+func (c *ConfigFile) Path() string {
+	return c.path
+}
+
+func (c *ConfigFile) Get(key string) (interface{}, bool) {
+	return c.doc.get(key)
+}
+
+func (c *ConfigFile) Has(key string) bool {
+	_, ok := c.doc.get(key)
+	return ok
+}
+
+func (c *ConfigFile) GetString(key, def string) string {
+	v, ok := c.doc.get(key)
+	if !ok || v == nil {
+		return def
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func (c *ConfigFile) GetInt(key string, def int) int {
+	v, ok := c.doc.get(key)
+	if !ok {
+		return def
+	}
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case uint64:
+		return int(n)
+	case float64:
+		if n == float64(int(n)) {
+			return int(n)
+		}
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+func (c *ConfigFile) GetFloat(key string, def float64) float64 {
+	v, ok := c.doc.get(key)
+	if !ok {
+		return def
+	}
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float64:
+		return n
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+func (c *ConfigFile) GetBool(key string, def bool) bool {
+	v, ok := c.doc.get(key)
+	if !ok {
+		return def
+	}
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		if parsed, err := strconv.ParseBool(strings.TrimSpace(b)); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+func (c *ConfigFile) Set(key string, value interface{}) error {
+	if err := c.doc.set(key, value); err != nil {
+		return fmt.Errorf("set %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *ConfigFile) Delete(key string) bool {
+	return c.doc.remove(key)
+}
+
+func (c *ConfigFile) Keys() []string {
+	return c.doc.keys()
+}
+
+func (c *ConfigFile) Bytes() ([]byte, error) {
+	return c.doc.encode()
+}
+
+func (c *ConfigFile) Changed() bool {
+	data, err := c.doc.encode()
+	return err != nil || !bytes.Equal(data, c.original)
+}
+
+func (c *ConfigFile) Diff() (string, error) {
+	data, err := c.doc.encode()
+	if err != nil {
+		return "", err
+	}
+	return Diff(c.path, c.path, c.original, data), nil
+}
+
+func (c *ConfigFile) Save() error {
+	if c.api == nil {
+		return ErrConfigDetached
+	}
+	data, err := c.doc.encode()
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, c.original) {
+		return nil
+	}
+	current, err := c.api.ReadFile(c.serverID, c.path)
+	if err != nil {
+		return err
+	}
+	if configSum(current) != c.sum {
+		return fmt.Errorf("%s: %w", c.path, ErrConfigConflict)
+	}
+	if err := c.api.WriteFile(c.serverID, c.path, data); err != nil {
+		return err
+	}
+	written, err := c.api.ReadFile(c.serverID, c.path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(written, data) {
+		return fmt.Errorf("%s: overwritten during save: %w", c.path, ErrConfigConflict)
+	}
+	c.original, c.sum = data, configSum(data)
+	return nil
+}
+
+func (c *ConfigFile) Reload() error {
+	if c.api == nil {
+		return ErrConfigDetached
+	}
+	fresh, err := c.api.OpenConfigAs(c.serverID, c.path, c.format)
+	if err != nil {
+		return err
+	}
+	*c = *fresh
+	return nil
+}
+
+func configString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type lineDialect struct {
+	comment     func(trimmed string) bool
+	section     func(trimmed string) (name string, array bool, ok bool)
+	entry       func(text string) (key string, keyEnd, start, end int, ok bool)
+	continues   func(text string) bool
+	decode      func(raw string) interface{}
+	encode      func(v interface{}) (string, error)
+	encodeKey   func(key string) string
+	sep         string
+	newSections bool
+}
+
+type configLine struct {
+	text    string
+	section string
+	key     string
+	start   int
+	end     int
+	entry   bool
+	header  bool
+	locked  bool
+}
+
+type lineConfig struct {
+	d        *lineDialect
+	lines    []configLine
+	sep      string
+	newline  string
+	trailing bool
+}
+
+func parseLineConfig(d *lineDialect, data []byte) *lineConfig {
+	c := &lineConfig{d: d, sep: d.sep, newline: "\n", trailing: len(data) == 0 || bytes.HasSuffix(data, []byte("\n"))}
+	text := string(data)
+	if strings.Contains(text, "\r\n") {
+		c.newline = "\r\n"
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return c
+	}
+
+	physical := strings.Split(text, "\n")
+	section, locked := "", false
+	sepFound := false
+	for i := 0; i < len(physical); i++ {
+		logical := physical[i]
+		for d.continues != nil && d.continues(logical) && i+1 < len(physical) {
+			i++
+			logical += "\n" + physical[i]
+		}
+		line := configLine{text: logical, section: section, locked: locked}
+		trimmed := strings.TrimSpace(logical)
+		switch {
+		case trimmed == "" || d.comment(trimmed):
+		case d.section != nil && isSectionLine(d, trimmed):
+			name, array, _ := d.section(trimmed)
+			section, locked = name, array
+			line.section, line.locked, line.header = name, array, true
+		default:
+			key, keyEnd, start, end, ok := d.entry(logical)
+			if !ok {
+				break
+			}
+			if !sepFound {
+				c.sep, sepFound = logical[keyEnd:start], true
+			}
+			line.entry, line.start, line.end = true, start, end
+			line.key = key
+			if section != "" {
+				line.key = section + "." + key
+			}
+		}
+		c.lines = append(c.lines, line)
+	}
+	return c
+}
+
+func isSectionLine(d *lineDialect, trimmed string) bool {
+	_, _, ok := d.section(trimmed)
+	return ok
+}
+
+func (c *lineConfig) get(key string) (interface{}, bool) {
+	for i := len(c.lines) - 1; i >= 0; i-- {
+		l := c.lines[i]
+		if l.entry && !l.locked && l.key == key {
+			return c.d.decode(l.text[l.start:l.end]), true
+		}
+	}
+	return nil, false
+}
+
+func (c *lineConfig) set(key string, value interface{}) error {
+	encoded, err := c.d.encode(value)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i := range c.lines {
+		l := &c.lines[i]
+		if l.entry && !l.locked && l.key == key {
+			l.text = l.text[:l.start] + encoded + l.text[l.end:]
+			l.end = l.start + len(encoded)
+			found = true
+		}
+	}
+	if !found {
+		c.insert(key, encoded)
+	}
+	return nil
+}
+
+func (c *lineConfig) insert(key, encoded string) {
+	section := ""
+	if c.d.section != nil {
+		for _, l := range c.lines {
+			if l.header && !l.locked && strings.HasPrefix(key, l.section+".") && len(l.section) > len(section) {
+				section = l.section
+			}
+		}
+		if section == "" && c.d.newSections {
+			if i := strings.LastIndex(key, "."); i > 0 {
+				c.appendSection(key[:i], key[i+1:], encoded)
+				return
+			}
+		}
+	}
+
+	local := key
+	if section != "" {
+		local = key[len(section)+1:]
+	}
+	text := c.d.encodeKey(local) + c.sep + encoded
+	line := configLine{text: text, section: section, key: key, entry: true}
+	line.start = len(text) - len(encoded)
+	line.end = len(text)
+
+	at := c.insertionPoint(section)
+	c.lines = append(c.lines, configLine{})
+	copy(c.lines[at+1:], c.lines[at:])
+	c.lines[at] = line
+}
+
+func (c *lineConfig) insertionPoint(section string) int {
+	at, firstHeader := -1, -1
+	for i, l := range c.lines {
+		if l.header && firstHeader < 0 {
+			firstHeader = i
+		}
+		if l.locked || l.section != section {
+			continue
+		}
+		if l.entry || (l.header && at < 0) {
+			at = i
+		}
+	}
+	if at >= 0 {
+		return at + 1
+	}
+	if firstHeader < 0 {
+		return len(c.lines)
+	}
+	for firstHeader > 0 && c.d.comment(strings.TrimSpace(c.lines[firstHeader-1].text)) {
+		firstHeader--
+	}
+	return firstHeader
+}
+
+func (c *lineConfig) appendSection(section, local, encoded string) {
+	if n := len(c.lines); n > 0 && strings.TrimSpace(c.lines[n-1].text) != "" {
+		c.lines = append(c.lines, configLine{})
+	}
+	text := c.d.encodeKey(local) + c.sep + encoded
+	c.lines = append(c.lines,
+		configLine{text: "[" + section + "]", section: section, header: true},
+		configLine{text: text, section: section, key: section + "." + local, entry: true, start: len(text) - len(encoded), end: len(text)},
+	)
+}
+
+func (c *lineConfig) remove(key string) bool {
+	kept := c.lines[:0]
+	removed := false
+	for _, l := range c.lines {
+		if l.entry && !l.locked && l.key == key {
+			removed = true
+			continue
+		}
+		kept = append(kept, l)
+	}
+	c.lines = kept
+	return removed
+}
+
+func (c *lineConfig) keys() []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, l := range c.lines {
+		if l.entry && !l.locked && !seen[l.key] {
+			seen[l.key] = true
+			out = append(out, l.key)
+		}
+	}
+	return out
+}
+
+func (c *lineConfig) encode() ([]byte, error) {
+	var b strings.Builder
+	for i, l := range c.lines {
+		if i > 0 {
+			b.WriteString(c.newline)
+		}
+		b.WriteString(strings.ReplaceAll(l.text, "\n", c.newline))
+	}
+	if c.trailing && len(c.lines) > 0 {
+		b.WriteString(c.newline)
+	}
+	return []byte(b.String()), nil
+}
+
+var propertiesDialect = &lineDialect{
+	comment: func(t string) bool { return t[0] == '#' || t[0] == '!' },
+	entry:   parsePropertiesEntry,
+	continues: func(text string) bool {
+		first, _, _ := strings.Cut(text, "\n")
+		if t := strings.TrimSpace(first); t != "" && (t[0] == '#' || t[0] == '!') {
+			return false
+		}
+		n := 0
+		for i := len(text) - 1; i >= 0 && text[i] == '\\'; i-- {
+			n++
+		}
+		return n%2 == 1
+	},
+	decode:    func(raw string) interface{} { return unescapeProperties(raw) },
+	encode:    func(v interface{}) (string, error) { return escapeProperties(configString(v), false), nil },
+	encodeKey: func(key string) string { return escapeProperties(key, true) },
+	sep:       "=",
+}
+
+func parsePropertiesEntry(text string) (string, int, int, int, bool) {
+	i := 0
+	for i < len(text) && isPropertiesSpace(text[i]) {
+		i++
+	}
+	keyStart := i
+	for i < len(text) {
+		c := text[i]
+		if c == '\\' {
+			i += 2
+			continue
+		}
+		if c == '=' || c == ':' || isPropertiesSpace(c) || c == '\n' {
+			break
+		}
+		i++
+	}
+	if i > len(text) {
+		i = len(text)
+	}
+	keyEnd := i
+	for i < len(text) && isPropertiesSpace(text[i]) {
+		i++
+	}
+	if i < len(text) && (text[i] == '=' || text[i] == ':') {
+		i++
+		for i < len(text) && isPropertiesSpace(text[i]) {
+			i++
+		}
+	}
+	if keyEnd == keyStart {
+		return "", 0, 0, 0, false
+	}
+	return unescapeProperties(text[keyStart:keyEnd]), keyEnd, i, len(text), true
+}
+
+func isPropertiesSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\f'
+}
+
+func unescapeProperties(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case '\n':
+			for i+1 < len(s) && isPropertiesSpace(s[i+1]) {
+				i++
+			}
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 16); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte('u')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func escapeProperties(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (key || i == 0):
+			b.WriteString(`\ `)
+		case key && strings.ContainsRune("=:#!", r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			if r > 0xffff {
+				r -= 0x10000
+				fmt.Fprintf(&b, `\u%04X\u%04X`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+			} else {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var iniDialect = &lineDialect{
+	comment: func(t string) bool { return t[0] == ';' || t[0] == '#' },
+	section: func(t string) (string, bool, bool) {
+		if len(t) < 2 || t[0] != '[' || t[len(t)-1] != ']' {
+			return "", false, false
+		}
+		return strings.TrimSpace(t[1 : len(t)-1]), false, true
+	},
+	entry: func(text string) (string, int, int, int, bool) {
+		eq := strings.IndexAny(text, "=:")
+		if eq <= 0 {
+			return "", 0, 0, 0, false
+		}
+		key := strings.TrimSpace(text[:eq])
+		keyEnd := strings.Index(text, key) + len(key)
+		start := eq + 1
+		for start < len(text) && (text[start] == ' ' || text[start] == '\t') {
+			start++
+		}
+		end := len(strings.TrimRight(text, " \t"))
+		if end < start {
+			end = start
+		}
+		return key, keyEnd, start, end, key != ""
+	},
+	decode: func(raw string) interface{} {
+		if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+			return raw[1 : len(raw)-1]
+		}
+		return raw
+	},
+	encode: func(v interface{}) (string, error) {
+		s := configString(v)
+		if strings.ContainsAny(s, "\r\n") {
+			return "", errors.New("ini values cannot span lines")
+		}
+		if s != strings.TrimSpace(s) || strings.ContainsAny(s, ";#") {
+			return `"` + s + `"`, nil
+		}
+		return s, nil
+	},
+	encodeKey:   func(key string) string { return key },
+	sep:         " = ",
+	newSections: true,
+}
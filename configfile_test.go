@@ -0,0 +1,94 @@
+package birdactyl
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+	"google.golang.org/grpc"
+)
+
+type configFilePanel struct {
+	pb.PanelServiceClient
+	files map[string][]byte
+}
+
+func (p *configFilePanel) ReadFile(ctx context.Context, in *pb.FilePathRequest, _ ...grpc.CallOption) (*pb.FileContent, error) {
+	return &pb.FileContent{Content: p.files[in.Path]}, nil
+}
+
+func (p *configFilePanel) WriteFile(ctx context.Context, in *pb.WriteFileRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+	p.files[in.Path] = in.Content
+	return &pb.Empty{}, nil
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		path string
+		in   string
+		set  map[string]interface{}
+		want string
+	}{
+		{
+			path: "server.properties",
+			in:   "#Minecraft server properties\n# generated\nmotd=A Server\nmax-players=20\nlevel-name=world\n",
+			set:  map[string]interface{}{"max-players": 50, "new-key": "v"},
+			want: "#Minecraft server properties\n# generated\nmotd=A Server\nmax-players=50\nlevel-name=world\nnew-key=v\n",
+		},
+		{
+			path: "config.ini",
+			in:   "; top comment\n[general]\nname = test\nport = 25565\n\n[extra]\n# note\nflag = true\n",
+			set:  map[string]interface{}{"general.port": 1, "extra.added": "yes"},
+			want: "; top comment\n[general]\nname = test\nport = 1\n\n[extra]\n# note\nflag = true\nadded = yes\n",
+		},
+		{
+			path: "config.toml",
+			in:   "# top\ntitle = \"x\"\n\n[server]\n# port comment\nport = 25565 # inline\nhost = \"0.0.0.0\"\n\n[limits]\nmax = 10\n",
+			set:  map[string]interface{}{"server.port": 1, "limits.min": 2},
+			want: "# top\ntitle = \"x\"\n\n[server]\n# port comment\nport = 1 # inline\nhost = \"0.0.0.0\"\n\n[limits]\nmax = 10\nmin = 2\n",
+		},
+		{
+			path: "config.yml",
+			in:   "# top comment\nserver:\n  # the port\n  port: 25565\n  host: 0.0.0.0 # inline\nlimits:\n  max: 10\n",
+			set:  map[string]interface{}{"server.port": 1, "limits.min": 2},
+			want: "# top comment\nserver:\n  # the port\n  port: 1\n  host: 0.0.0.0 # inline\nlimits:\n  max: 10\n  min: 2\n",
+		},
+		{
+			path: "config.json",
+			in:   "{\n  \"server\": {\n    \"port\": 25565,\n    \"host\": \"0.0.0.0\"\n  },\n  \"limits\": {\n    \"max\": 10\n  }\n}\n",
+			set:  map[string]interface{}{"server.port": 1, "limits.min": 2},
+			want: "{\n  \"server\": {\n    \"port\": 1,\n    \"host\": \"0.0.0.0\"\n  },\n  \"limits\": {\n    \"max\": 10,\n    \"min\": 2\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			panel := &configFilePanel{files: map[string][]byte{tt.path: []byte(tt.in)}}
+			api := &API{panel: panel}
+			err := api.EditConfig("server", tt.path, func(c *ConfigFile) error {
+				for key, value := range tt.set {
+					if err := c.Set(key, value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("EditConfig: %v", err)
+			}
+			if got := string(panel.files[tt.path]); got != tt.want {
+				t.Errorf("saved file mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			c, err := api.OpenConfig("server", tt.path)
+			if err != nil {
+				t.Fatalf("OpenConfig: %v", err)
+			}
+			for key, value := range tt.set {
+				if got, ok := c.Get(key); !ok || configString(got) != configString(value) {
+					t.Errorf("Get(%q) = %v, %v; want %v", key, got, ok, value)
+				}
+			}
+		})
+	}
+}
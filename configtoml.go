@@ -0,0 +1,436 @@
+package birdactyl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var tomlDialect = &lineDialect{
+	comment: func(t string) bool { return t[0] == '#' },
+	section: func(t string) (string, bool, bool) {
+		if len(t) < 2 || t[0] != '[' {
+			return "", false, false
+		}
+		array := strings.HasPrefix(t, "[[")
+		open := 1
+		if array {
+			open = 2
+		}
+		end := tomlScanKey(t, open, ']')
+		if end < 0 || (array && (end+1 >= len(t) || t[end+1] != ']')) {
+			return "", false, false
+		}
+		return strings.Join(splitTOMLKey(t[open:end]), "."), array, true
+	},
+	entry: func(text string) (string, int, int, int, bool) {
+		eq := tomlScanKey(text, 0, '=')
+		if eq <= 0 {
+			return "", 0, 0, 0, false
+		}
+		keyEnd := len(strings.TrimRight(text[:eq], " \t"))
+		start := eq + 1
+		for start < len(text) && (text[start] == ' ' || text[start] == '\t') {
+			start++
+		}
+		end, _ := tomlValueEnd(text, start)
+		return strings.Join(splitTOMLKey(text[:eq]), "."), keyEnd, start, end, true
+	},
+	continues: func(text string) bool {
+		t := strings.TrimSpace(text)
+		if t == "" || t[0] == '#' || t[0] == '[' {
+			return false
+		}
+		eq := tomlScanKey(text, 0, '=')
+		if eq <= 0 {
+			return false
+		}
+		_, complete := tomlValueEnd(text, eq+1)
+		return !complete
+	},
+	decode:    decodeTOMLValue,
+	encode:    encodeTOMLValue,
+	encodeKey: encodeTOMLKey,
+	sep:       " = ",
+}
+
+func tomlScanKey(s string, from int, stop byte) int {
+	var quote byte
+	for i := from; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == stop:
+			return i
+		case c == '#' || c == '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+func tomlValueEnd(s string, start int) (int, bool) {
+	depth := 0
+	end := start
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case strings.HasPrefix(s[i:], `"""`) || strings.HasPrefix(s[i:], `'''`):
+			delim := s[i : i+3]
+			j := strings.Index(s[i+3:], delim)
+			for delim == `"""` && j >= 0 && tomlEscaped(s, i+3+j) {
+				k := strings.Index(s[i+3+j+1:], delim)
+				if k < 0 {
+					j = -1
+					break
+				}
+				j += 1 + k
+			}
+			if j < 0 {
+				return len(s), false
+			}
+			i += 3 + j + 2
+			for i+1 < len(s) && s[i+1] == delim[0] {
+				i++
+			}
+			end = i + 1
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(s) && s[j] != c && s[j] != '\n' {
+				if c == '"' && s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			i = j
+			end = j + 1
+		case c == '[' || c == '{':
+			depth++
+			end = i + 1
+		case c == ']' || c == '}':
+			depth--
+			end = i + 1
+		case c == '#':
+			if depth == 0 {
+				return end, true
+			}
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			end = i + 1
+		}
+	}
+	if end > len(s) {
+		end = len(s)
+	}
+	return end, depth <= 0
+}
+
+func tomlEscaped(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func splitTOMLKey(s string) []string {
+	var parts []string
+	var b strings.Builder
+	var quote byte
+	flush := func() {
+		part := strings.TrimSpace(b.String())
+		if len(part) >= 2 && part[0] == '"' {
+			part = tomlUnescape(part[1 : len(part)-1])
+		} else if len(part) >= 2 && part[0] == '\'' {
+			part = part[1 : len(part)-1]
+		}
+		parts = append(parts, part)
+		b.Reset()
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' && i+1 < len(s) {
+				b.WriteByte(c)
+				i++
+				c = s[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			flush()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	flush()
+	return parts
+}
+
+func encodeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, p := range parts {
+		if !tomlBareKeyRe.MatchString(p) {
+			parts[i] = tomlQuote(p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func decodeTOMLValue(raw string) interface{} {
+	switch {
+	case strings.HasPrefix(raw, `"""`) && len(raw) >= 6:
+		return tomlUnescape(strings.TrimPrefix(strings.TrimPrefix(raw[3:len(raw)-3], "\r"), "\n"))
+	case strings.HasPrefix(raw, `'''`) && len(raw) >= 6:
+		return strings.TrimPrefix(strings.TrimPrefix(raw[3:len(raw)-3], "\r"), "\n")
+	case strings.HasPrefix(raw, `"`) && len(raw) >= 2:
+		return tomlUnescape(raw[1 : len(raw)-1])
+	case strings.HasPrefix(raw, `'`) && len(raw) >= 2:
+		return raw[1 : len(raw)-1]
+	case raw == "true":
+		return true
+	case raw == "false":
+		return false
+	case strings.HasPrefix(raw, "["):
+		return decodeTOMLArray(raw)
+	case strings.HasPrefix(raw, "{"):
+		return raw
+	}
+	if i, err := strconv.ParseInt(raw, 0, 64); err == nil {
+		return i
+	}
+	switch strings.TrimLeft(raw, "+-") {
+	case "inf":
+		if strings.HasPrefix(raw, "-") {
+			return math.Inf(-1)
+		}
+		return math.Inf(1)
+	case "nan":
+		return math.NaN()
+	}
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err == nil {
+		return f
+	}
+	return raw
+}
+
+func decodeTOMLArray(raw string) []interface{} {
+	out := []interface{}{}
+	body := raw[1 : len(raw)-1]
+	for len(strings.TrimSpace(body)) > 0 {
+		body = strings.TrimLeft(body, " \t\r\n,")
+		if strings.HasPrefix(body, "#") {
+			_, body, _ = strings.Cut(body, "\n")
+			continue
+		}
+		if body == "" {
+			break
+		}
+		end, _ := tomlValueEnd(body, 0)
+		elem := body[:end]
+		if i := tomlTopLevelComma(elem); i >= 0 {
+			elem, end = elem[:i], i
+		}
+		out = append(out, decodeTOMLValue(strings.TrimSpace(elem)))
+		body = body[end:]
+	}
+	return out
+}
+
+func tomlTopLevelComma(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			return i
+		}
+	}
+	return -1
+}
+
+func tomlUnescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case 'e':
+			b.WriteByte(0x1b)
+		case 'u', 'U':
+			n := 4
+			if s[i] == 'U' {
+				n = 8
+			}
+			if i+n < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += n
+					continue
+				}
+			}
+			b.WriteByte(s[i])
+		case ' ', '\t', '\r', '\n':
+			for i+1 < len(s) && strings.IndexByte(" \t\r\n", s[i+1]) >= 0 {
+				i++
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func encodeTOMLValue(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", errors.New("toml has no null value")
+	case string:
+		return tomlQuote(t), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case time.Time:
+		return t.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return tomlQuote(t.String()), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			return "nan", nil
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		}
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return s, nil
+	case reflect.String:
+		return tomlQuote(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			s, err := encodeTOMLValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("toml tables need string keys, got %s", rv.Type().Key())
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			s, err := encodeTOMLValue(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return "", err
+			}
+			parts[i] = encodeTOMLKey(k) + " = " + s
+		}
+		if len(parts) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	}
+	return "", fmt.Errorf("cannot encode %T as toml", v)
+}
@@ -0,0 +1,482 @@
+package birdactyl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func splitConfigKey(key string) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\' && i+1 < len(key) && key[i+1] == '.':
+			b.WriteByte('.')
+			i++
+		case key[i] == '.':
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(key[i])
+		}
+	}
+	return append(parts, b.String())
+}
+
+func joinConfigKey(prefix, part string) string {
+	part = strings.ReplaceAll(part, ".", `\.`)
+	if prefix == "" {
+		return part
+	}
+	return prefix + "." + part
+}
+
+func detectIndent(data []byte, def string) string {
+	lines := strings.Split(string(data), "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || trimmed[0] == '#' || len(trimmed) == len(line) {
+			continue
+		}
+		return line[:len(line)-len(trimmed)]
+	}
+	return def
+}
+
+type yamlConfig struct {
+	root   *yaml.Node
+	indent int
+}
+
+func parseYAMLConfig(data []byte) (*yamlConfig, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	indent := len(strings.ReplaceAll(detectIndent(data, "  "), "\t", "  "))
+	if indent < 2 {
+		indent = 2
+	}
+	return &yamlConfig{root: &doc, indent: indent}, nil
+}
+
+func (c *yamlConfig) lookup(parts []string, create bool) (*yaml.Node, error) {
+	n := c.root.Content[0]
+	for i, part := range parts {
+		for n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		switch n.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for j := 0; j+1 < len(n.Content); j += 2 {
+				if n.Content[j].Value == part {
+					next = n.Content[j+1]
+					break
+				}
+			}
+			if next == nil {
+				if !create {
+					return nil, nil
+				}
+				next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, next)
+			}
+			n = next
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(part)
+			switch {
+			case err == nil && idx >= 0 && idx < len(n.Content):
+				n = n.Content[idx]
+			case create && err == nil && idx == len(n.Content):
+				next := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				n.Content = append(n.Content, next)
+				n = next
+			case create:
+				return nil, fmt.Errorf("%s is not a valid index into %s", part, strings.Join(parts[:i], "."))
+			default:
+				return nil, nil
+			}
+		default:
+			if create {
+				return nil, fmt.Errorf("%s is not a mapping", strings.Join(parts[:i], "."))
+			}
+			return nil, nil
+		}
+	}
+	return n, nil
+}
+
+func (c *yamlConfig) get(key string) (interface{}, bool) {
+	n, _ := c.lookup(splitConfigKey(key), false)
+	if n == nil {
+		return nil, false
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (c *yamlConfig) set(key string, value interface{}) error {
+	target, err := c.lookup(splitConfigKey(key), true)
+	if err != nil {
+		return err
+	}
+	var n yaml.Node
+	if err := n.Encode(value); err != nil {
+		return err
+	}
+	n.HeadComment, n.LineComment, n.FootComment = target.HeadComment, target.LineComment, target.FootComment
+	if n.Kind == yaml.ScalarNode && target.Kind == yaml.ScalarNode && n.Tag == "!!str" && target.Tag == "!!str" {
+		n.Style = target.Style
+	}
+	*target = n
+	return nil
+}
+
+func (c *yamlConfig) remove(key string) bool {
+	parts := splitConfigKey(key)
+	parent, _ := c.lookup(parts[:len(parts)-1], false)
+	if parent == nil {
+		return false
+	}
+	last := parts[len(parts)-1]
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for j := 0; j+1 < len(parent.Content); j += 2 {
+			if parent.Content[j].Value == last {
+				parent.Content = append(parent.Content[:j], parent.Content[j+2:]...)
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		if idx, err := strconv.Atoi(last); err == nil && idx >= 0 && idx < len(parent.Content) {
+			parent.Content = append(parent.Content[:idx], parent.Content[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *yamlConfig) keys() []string {
+	var out []string
+	var walk func(n *yaml.Node, prefix string)
+	walk = func(n *yaml.Node, prefix string) {
+		if n.Kind == yaml.MappingNode && len(n.Content) > 0 {
+			for j := 0; j+1 < len(n.Content); j += 2 {
+				walk(n.Content[j+1], joinConfigKey(prefix, n.Content[j].Value))
+			}
+			return
+		}
+		if prefix != "" {
+			out = append(out, prefix)
+		}
+	}
+	walk(c.root.Content[0], "")
+	return out
+}
+
+func (c *yamlConfig) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(c.indent)
+	if err := enc.Encode(c.root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type jsonNode struct {
+	kind  byte
+	keys  []string
+	items []*jsonNode
+	value interface{}
+}
+
+type jsonConfig struct {
+	root     *jsonNode
+	indent   string
+	trailing bool
+}
+
+func parseJSONConfig(data []byte) (*jsonConfig, error) {
+	c := &jsonConfig{root: &jsonNode{kind: '{'}, trailing: bytes.HasSuffix(data, []byte("\n"))}
+	if len(bytes.TrimSpace(data)) == 0 {
+		c.indent, c.trailing = "  ", true
+		return c, nil
+	}
+	if bytes.Contains(bytes.TrimSpace(data), []byte("\n")) {
+		c.indent = detectIndent(data, "  ")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeJSONNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	c.root = root
+	return c, nil
+}
+
+func decodeJSONNode(dec *json.Decoder) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &jsonNode{value: tok}, nil
+	}
+	n := &jsonNode{kind: byte(delim)}
+	for dec.More() {
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key.(string))
+		}
+		item, err := decodeJSONNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func jsonNodeOf(v interface{}) (*jsonNode, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeJSONNode(dec)
+}
+
+func (n *jsonNode) interfaceValue() interface{} {
+	switch n.kind {
+	case '{':
+		m := make(map[string]interface{}, len(n.keys))
+		for i, k := range n.keys {
+			m[k] = n.items[i].interfaceValue()
+		}
+		return m
+	case '[':
+		s := make([]interface{}, len(n.items))
+		for i, item := range n.items {
+			s[i] = item.interfaceValue()
+		}
+		return s
+	}
+	if num, ok := n.value.(json.Number); ok {
+		if i, err := num.Int64(); err == nil {
+			return i
+		}
+		f, _ := num.Float64()
+		return f
+	}
+	return n.value
+}
+
+func (c *jsonConfig) lookup(parts []string, create bool) (*jsonNode, error) {
+	n := c.root
+	for i, part := range parts {
+		switch n.kind {
+		case '{':
+			var next *jsonNode
+			for j, k := range n.keys {
+				if k == part {
+					next = n.items[j]
+				}
+			}
+			if next == nil {
+				if !create {
+					return nil, nil
+				}
+				next = &jsonNode{kind: '{'}
+				n.keys = append(n.keys, part)
+				n.items = append(n.items, next)
+			}
+			n = next
+		case '[':
+			idx, err := strconv.Atoi(part)
+			switch {
+			case err == nil && idx >= 0 && idx < len(n.items):
+				n = n.items[idx]
+			case create && err == nil && idx == len(n.items):
+				next := &jsonNode{kind: '{'}
+				n.items = append(n.items, next)
+				n = next
+			case create:
+				return nil, fmt.Errorf("%s is not a valid index into %s", part, strings.Join(parts[:i], "."))
+			default:
+				return nil, nil
+			}
+		default:
+			if create {
+				return nil, fmt.Errorf("%s is not an object", strings.Join(parts[:i], "."))
+			}
+			return nil, nil
+		}
+	}
+	return n, nil
+}
+
+func (c *jsonConfig) get(key string) (interface{}, bool) {
+	n, _ := c.lookup(splitConfigKey(key), false)
+	if n == nil {
+		return nil, false
+	}
+	return n.interfaceValue(), true
+}
+
+func (c *jsonConfig) set(key string, value interface{}) error {
+	target, err := c.lookup(splitConfigKey(key), true)
+	if err != nil {
+		return err
+	}
+	n, err := jsonNodeOf(value)
+	if err != nil {
+		return err
+	}
+	*target = *n
+	return nil
+}
+
+func (c *jsonConfig) remove(key string) bool {
+	parts := splitConfigKey(key)
+	parent, _ := c.lookup(parts[:len(parts)-1], false)
+	if parent == nil {
+		return false
+	}
+	last := parts[len(parts)-1]
+	switch parent.kind {
+	case '{':
+		for j, k := range parent.keys {
+			if k == last {
+				parent.keys = append(parent.keys[:j], parent.keys[j+1:]...)
+				parent.items = append(parent.items[:j], parent.items[j+1:]...)
+				return true
+			}
+		}
+	case '[':
+		if idx, err := strconv.Atoi(last); err == nil && idx >= 0 && idx < len(parent.items) {
+			parent.items = append(parent.items[:idx], parent.items[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *jsonConfig) keys() []string {
+	var out []string
+	var walk func(n *jsonNode, prefix string)
+	walk = func(n *jsonNode, prefix string) {
+		if n.kind == '{' && len(n.keys) > 0 {
+			for i, k := range n.keys {
+				walk(n.items[i], joinConfigKey(prefix, k))
+			}
+			return
+		}
+		if prefix != "" {
+			out = append(out, prefix)
+		}
+	}
+	walk(c.root, "")
+	return out
+}
+
+func (c *jsonConfig) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.write(&buf, c.root, 0); err != nil {
+		return nil, err
+	}
+	if c.trailing {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *jsonConfig) write(buf *bytes.Buffer, n *jsonNode, depth int) error {
+	if n.kind == 0 {
+		return writeJSONScalar(buf, n.value)
+	}
+	closing := byte('}')
+	if n.kind == '[' {
+		closing = ']'
+	}
+	buf.WriteByte(n.kind)
+	if len(n.items) == 0 {
+		buf.WriteByte(closing)
+		return nil
+	}
+	for i, item := range n.items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if c.indent != "" {
+			buf.WriteByte('\n')
+			buf.WriteString(strings.Repeat(c.indent, depth+1))
+		}
+		if n.kind == '{' {
+			if err := writeJSONScalar(buf, n.keys[i]); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if c.indent != "" {
+				buf.WriteByte(' ')
+			}
+		}
+		if err := c.write(buf, item, depth+1); err != nil {
+			return err
+		}
+	}
+	if c.indent != "" {
+		buf.WriteByte('\n')
+		buf.WriteString(strings.Repeat(c.indent, depth))
+	}
+	buf.WriteByte(closing)
+	return nil
+}
+
+func writeJSONScalar(buf *bytes.Buffer, v interface{}) error {
+	if num, ok := v.(json.Number); ok {
+		buf.WriteString(num.String())
+		return nil
+	}
+	var tmp bytes.Buffer
+	enc := json.NewEncoder(&tmp)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimRight(tmp.Bytes(), "\n"))
+	return nil
+}
@@ -0,0 +1,245 @@
+package birdactyl
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ConsoleLine struct {
+	ServerID  string
+	Line      string
+	Timestamp time.Time
+}
+
+type ConsoleOptions struct {
+	HistoryLines int32
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+	Buffer       int
+}
+
+var DefaultConsoleOptions = ConsoleOptions{
+	HistoryLines: 200,
+	MinBackoff:   time.Second,
+	MaxBackoff:   30 * time.Second,
+	Buffer:       256,
+}
+
+type Console struct {
+	mu      sync.Mutex
+	api     *API
+	opts    ConsoleOptions
+	feeds   map[string]*consoleFeed
+	nextSub int
+}
+
+type ConsoleSubscription struct {
+	feed    *consoleFeed
+	id      int
+	ch      chan ConsoleLine
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+type consoleFeed struct {
+	console  *Console
+	serverID string
+	subs     map[int]*ConsoleSubscription
+	ready    chan struct{}
+	readyOne sync.Once
+	cancel   context.CancelFunc
+	lastTS   int64
+	atLast   map[string]int
+}
+
+func newConsole(opts ConsoleOptions) *Console {
+	c := &Console{feeds: make(map[string]*consoleFeed)}
+	c.SetOptions(opts)
+	return c
+}
+
+func (c *Console) SetOptions(opts ConsoleOptions) {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultConsoleOptions.MinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = DefaultConsoleOptions.Buffer
+	}
+	c.mu.Lock()
+	c.opts = opts
+	c.mu.Unlock()
+}
+
+func (c *Console) attach(api *API) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.api = api
+	for _, f := range c.feeds {
+		f.start()
+	}
+}
+
+func (c *Console) Subscribe(serverID string, fn func(ConsoleLine)) *ConsoleSubscription {
+	sub := c.subscribe(serverID)
+	go func() {
+		for line := range sub.ch {
+			fn(line)
+		}
+	}()
+	return sub
+}
+
+func (c *Console) Watch(ctx context.Context, serverID string) <-chan ConsoleLine {
+	sub := c.subscribe(serverID)
+	context.AfterFunc(ctx, sub.Close)
+	return sub.ch
+}
+
+func (c *Console) subscribe(serverID string) *ConsoleSubscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, ok := c.feeds[serverID]
+	if !ok {
+		f = &consoleFeed{console: c, serverID: serverID, subs: make(map[int]*ConsoleSubscription), ready: make(chan struct{})}
+		c.feeds[serverID] = f
+		if c.api != nil {
+			f.start()
+		}
+	}
+	c.nextSub++
+	sub := &ConsoleSubscription{feed: f, id: c.nextSub, ch: make(chan ConsoleLine, c.opts.Buffer)}
+	f.subs[sub.id] = sub
+	return sub
+}
+
+func (s *ConsoleSubscription) Ready() <-chan struct{} {
+	return s.feed.ready
+}
+
+func (s *ConsoleSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+func (s *ConsoleSubscription) Close() {
+	s.once.Do(func() {
+		c := s.feed.console
+		c.mu.Lock()
+		delete(s.feed.subs, s.id)
+		if len(s.feed.subs) == 0 {
+			if s.feed.cancel != nil {
+				s.feed.cancel()
+			}
+			if c.feeds[s.feed.serverID] == s.feed {
+				delete(c.feeds, s.feed.serverID)
+			}
+		}
+		close(s.ch)
+		c.mu.Unlock()
+	})
+}
+
+func (f *consoleFeed) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	go f.run(ctx, f.console.api.WithContext(ctx))
+}
+
+func (f *consoleFeed) run(ctx context.Context, api *API) {
+	f.console.mu.Lock()
+	opts := f.console.opts
+	f.console.mu.Unlock()
+	backoff := opts.MinBackoff
+	reconnect := false
+
+	for ctx.Err() == nil {
+		stream, err := api.StreamConsole(f.serverID, reconnect, opts.HistoryLines)
+		if err == nil {
+			f.readyOne.Do(func() { close(f.ready) })
+			skip := make(map[string]int, len(f.atLast))
+			for k, v := range f.atLast {
+				skip[k] = v
+			}
+			replaying := reconnect
+			for {
+				var line ConsoleLine
+				if line, err = stream.RecvLine(); err != nil {
+					break
+				}
+				backoff = opts.MinBackoff
+				if ts := consoleMillis(line.Timestamp); replaying {
+					if ts < f.lastTS {
+						continue
+					}
+					if ts == f.lastTS && skip[line.Line] > 0 {
+						skip[line.Line]--
+						continue
+					}
+					replaying = false
+				}
+				f.publish(line)
+			}
+			stream.Close()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("[console] stream for %s failed: %v", f.serverID, err)
+		}
+
+		reconnect = true
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > opts.MaxBackoff {
+			backoff = opts.MaxBackoff
+		}
+	}
+}
+
+func (f *consoleFeed) publish(line ConsoleLine) {
+	text, ts := line.Line, consoleMillis(line.Timestamp)
+	switch {
+	case ts > f.lastTS || f.atLast == nil:
+		f.lastTS = ts
+		f.atLast = map[string]int{text: 1}
+	case ts == f.lastTS && len(f.atLast) < 1024:
+		f.atLast[text]++
+	}
+
+	c := f.console
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, sub := range f.subs {
+		select {
+		case sub.ch <- line:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+func consoleTime(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ts)
+}
+
+func consoleMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,62 @@
+package birdactyl
+
+import "sync"
+
+type eventBus struct {
+	mu        sync.Mutex
+	listeners map[string]map[int]func(Event)
+	declared  map[string]bool
+	next      int
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{listeners: make(map[string]map[int]func(Event)), declared: make(map[string]bool)}
+}
+
+func (b *eventBus) declare(types ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range types {
+		b.declared[t] = true
+	}
+}
+
+func (b *eventBus) types() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]string, 0, len(b.declared))
+	for t := range b.declared {
+		out = append(out, t)
+	}
+	return out
+}
+
+func (b *eventBus) subscribe(eventType string, fn func(Event)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.next++
+	id := b.next
+	if b.listeners[eventType] == nil {
+		b.listeners[eventType] = make(map[int]func(Event))
+	}
+	b.listeners[eventType][id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.listeners[eventType], id)
+	}
+}
+
+func (b *eventBus) publish(ev Event) {
+	b.mu.Lock()
+	var fns []func(Event)
+	for _, key := range []string{ev.Type, "*"} {
+		for _, fn := range b.listeners[key] {
+			fns = append(fns, fn)
+		}
+	}
+	b.mu.Unlock()
+	for _, fn := range fns {
+		fn(ev)
+	}
+}
@@ -0,0 +1,134 @@
+package birdactyl
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrExecTimeout = errors.New("birdactyl: exec timed out before the terminator matched")
+
+type ExecOptions struct {
+	Until    string
+	Match    string
+	Quiet    time.Duration
+	Timeout  time.Duration
+	Settle   time.Duration
+	MaxLines int
+}
+
+type ExecResult struct {
+	Command string
+	Lines   []ConsoleLine
+	Groups  []string
+	Reason  string
+}
+
+const (
+	ExecReasonMatched  = "matched"
+	ExecReasonQuiet    = "quiet"
+	ExecReasonMaxLines = "max_lines"
+	ExecReasonTimeout  = "timeout"
+)
+
+func (r *ExecResult) Output() string {
+	parts := make([]string, len(r.Lines))
+	for i, l := range r.Lines {
+		parts[i] = l.Line
+	}
+	return strings.Join(parts, "\n")
+}
+
+func (a *API) Exec(serverID, command string, opts ExecOptions) (*ExecResult, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Until == "" && opts.Quiet <= 0 {
+		opts.Quiet = 500 * time.Millisecond
+	}
+	if opts.Settle <= 0 {
+		opts.Settle = 200 * time.Millisecond
+	}
+
+	var until, match *regexp.Regexp
+	var err error
+	if opts.Until != "" {
+		if until, err = regexp.Compile(opts.Until); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Match != "" {
+		if match, err = regexp.Compile(opts.Match); err != nil {
+			return nil, err
+		}
+	}
+
+	console := a.console
+	if console == nil {
+		console = newConsole(DefaultConsoleOptions)
+		console.attach(a)
+	}
+	sub := console.subscribe(serverID)
+	defer sub.Close()
+
+	deadline := time.NewTimer(opts.Timeout)
+	defer deadline.Stop()
+
+	select {
+	case <-sub.Ready():
+	default:
+		select {
+		case <-sub.Ready():
+			time.Sleep(opts.Settle)
+		case <-deadline.C:
+			return nil, ErrExecTimeout
+		}
+	}
+
+	if err := a.SendCommand(serverID, command); err != nil {
+		return nil, err
+	}
+
+	res := &ExecResult{Command: command}
+	var quiet <-chan time.Time
+	if opts.Quiet > 0 {
+		quiet = time.After(opts.Quiet)
+	}
+	for {
+		select {
+		case line, ok := <-sub.ch:
+			if !ok {
+				res.Reason = ExecReasonTimeout
+				return res, ErrExecTimeout
+			}
+			text := StripANSI(line.Line)
+			if match == nil || match.MatchString(text) {
+				res.Lines = append(res.Lines, line)
+			}
+			if until != nil {
+				if groups := until.FindStringSubmatch(text); groups != nil {
+					res.Groups = groups
+					res.Reason = ExecReasonMatched
+					return res, nil
+				}
+			}
+			if opts.MaxLines > 0 && len(res.Lines) >= opts.MaxLines {
+				res.Reason = ExecReasonMaxLines
+				return res, nil
+			}
+			if opts.Quiet > 0 {
+				quiet = time.After(opts.Quiet)
+			}
+		case <-quiet:
+			res.Reason = ExecReasonQuiet
+			return res, nil
+		case <-deadline.C:
+			res.Reason = ExecReasonTimeout
+			if until != nil {
+				return res, ErrExecTimeout
+			}
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package birdactyl
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrQueueFull = errors.New("birdactyl: executor queue is full")
+
+type ExecutorConfig struct {
+	MaxInFlight  int
+	MaxQueued    int
+	MethodLimits map[string]int
+}
+
+var DefaultExecutorConfig = ExecutorConfig{MaxInFlight: 32}
+
+type ExecutorStats struct {
+	Running int
+	Queued  int
+}
+
+type Executor struct {
+	mu      sync.Mutex
+	cfg     ExecutorConfig
+	running int
+	methods map[string]int
+	queue   []*task
+}
+
+type task struct {
+	ctx    context.Context
+	method string
+	run    func(context.Context)
+	fail   func(error)
+	stop   func() bool
+}
+
+func NewExecutor(cfg ExecutorConfig) *Executor {
+	limits := make(map[string]int, len(cfg.MethodLimits))
+	for k, v := range cfg.MethodLimits {
+		limits[k] = v
+	}
+	cfg.MethodLimits = limits
+	return &Executor{cfg: cfg, methods: make(map[string]int)}
+}
+
+func (e *Executor) SetMaxInFlight(n int) *Executor {
+	e.mu.Lock()
+	e.cfg.MaxInFlight = n
+	e.mu.Unlock()
+	e.dispatch()
+	return e
+}
+
+func (e *Executor) SetMethodLimit(method string, n int) *Executor {
+	e.mu.Lock()
+	if n > 0 {
+		e.cfg.MethodLimits[method] = n
+	} else {
+		delete(e.cfg.MethodLimits, method)
+	}
+	e.mu.Unlock()
+	e.dispatch()
+	return e
+}
+
+func (e *Executor) Stats() ExecutorStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return ExecutorStats{Running: e.running, Queued: len(e.queue)}
+}
+
+func (e *Executor) submit(ctx context.Context, method string, run func(context.Context), fail func(error)) {
+	if err := ctx.Err(); err != nil {
+		fail(err)
+		return
+	}
+	t := &task{ctx: ctx, method: method, run: run, fail: fail}
+
+	e.mu.Lock()
+	if e.cfg.MaxQueued > 0 && len(e.queue) >= e.cfg.MaxQueued {
+		e.mu.Unlock()
+		fail(ErrQueueFull)
+		return
+	}
+	e.queue = append(e.queue, t)
+	t.stop = context.AfterFunc(ctx, func() {
+		if e.remove(t) {
+			t.fail(ctx.Err())
+		}
+	})
+	e.mu.Unlock()
+
+	e.dispatch()
+}
+
+func (e *Executor) remove(t *task) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, q := range e.queue {
+		if q == t {
+			e.queue = append(e.queue[:i], e.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Executor) dispatch() {
+	e.mu.Lock()
+	var start []*task
+	for i := 0; i < len(e.queue); {
+		if e.cfg.MaxInFlight > 0 && e.running >= e.cfg.MaxInFlight {
+			break
+		}
+		t := e.queue[i]
+		if limit := e.cfg.MethodLimits[t.method]; limit > 0 && e.methods[t.method] >= limit {
+			i++
+			continue
+		}
+		e.queue = append(e.queue[:i], e.queue[i+1:]...)
+		e.running++
+		e.methods[t.method]++
+		start = append(start, t)
+	}
+	e.mu.Unlock()
+
+	for _, t := range start {
+		t.stop()
+		go e.run(t)
+	}
+}
+
+func (e *Executor) run(t *task) {
+	defer func() {
+		e.mu.Lock()
+		e.running--
+		if e.methods[t.method]--; e.methods[t.method] <= 0 {
+			delete(e.methods, t.method)
+		}
+		e.mu.Unlock()
+		e.dispatch()
+	}()
+	t.run(t.ctx)
+}
+
+func ForEach[T any](ctx context.Context, items []T, concurrency int, fn func(context.Context, T) error) error {
+	if concurrency <= 0 || concurrency > len(items) {
+		concurrency = len(items)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	work := make(chan T)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				if err := fn(ctx, item); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, item := range items {
+		select {
+		case work <- item:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
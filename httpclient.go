@@ -0,0 +1,242 @@
+package birdactyl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strings"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+var ErrResponseTooLarge = errors.New("birdactyl: http response exceeds size limit")
+
+type HTTPError struct {
+	Method string
+	URL    string
+	Status int
+	Body   []byte
+	Err    string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != "" {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Err)
+	}
+	return fmt.Sprintf("%s %s: status %d", e.Method, e.URL, e.Status)
+}
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+	RetryStatus    func(status int) bool
+	NonIdempotent  bool
+	RetryTransport bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: 500 * time.Millisecond, MaxBackoff: 5 * time.Second, RetryTransport: true}
+
+type HTTPClient struct {
+	api     *API
+	baseURL string
+	headers map[string]string
+	timeout time.Duration
+	retry   RetryPolicy
+	maxBody int
+}
+
+func (a *API) HTTPClient() *HTTPClient {
+	return &HTTPClient{api: a, headers: make(map[string]string), timeout: 30 * time.Second, retry: RetryPolicy{MaxAttempts: 1}}
+}
+
+func (c *HTTPClient) BaseURL(u string) *HTTPClient {
+	c.baseURL = strings.TrimRight(u, "/")
+	return c
+}
+
+func (c *HTTPClient) Header(key, value string) *HTTPClient {
+	c.headers[key] = value
+	return c
+}
+
+func (c *HTTPClient) BearerToken(token string) *HTTPClient {
+	return c.Header("Authorization", "Bearer "+token)
+}
+
+func (c *HTTPClient) Timeout(d time.Duration) *HTTPClient {
+	c.timeout = d
+	return c
+}
+
+func (c *HTTPClient) Retry(policy RetryPolicy) *HTTPClient {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = DefaultRetryPolicy.MinBackoff
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	c.retry = policy
+	return c
+}
+
+func (c *HTTPClient) MaxResponseSize(n int) *HTTPClient {
+	c.maxBody = n
+	return c
+}
+
+func (c *HTTPClient) WithContext(ctx context.Context) *HTTPClient {
+	cp := *c
+	cp.api = c.api.WithContext(ctx)
+	return &cp
+}
+
+func (c *HTTPClient) resolve(path string, query url.Values) string {
+	u := path
+	if c.baseURL != "" && !strings.Contains(path, "://") {
+		u = c.baseURL + "/" + strings.TrimLeft(path, "/")
+	}
+	if len(query) > 0 {
+		sep := "?"
+		if strings.Contains(u, "?") {
+			sep = "&"
+		}
+		u += sep + query.Encode()
+	}
+	return u
+}
+
+func (c *HTTPClient) Do(method, path string, query url.Values, headers map[string]string, body []byte) (*HTTPResponse, error) {
+	u := c.resolve(path, query)
+	h := make(map[string]string, len(c.headers)+len(headers))
+	for k, v := range c.headers {
+		h[k] = v
+	}
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	policy := c.retry
+	idempotent := method != "POST" && method != "PATCH"
+	backoff := policy.MinBackoff
+	var last error
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(method, u, h, body)
+		if err == nil {
+			return resp, nil
+		}
+		last = err
+		if attempt >= policy.MaxAttempts || (!idempotent && !policy.NonIdempotent) || !policy.retryable(err) {
+			return resp, last
+		}
+
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		select {
+		case <-time.After(wait):
+		case <-c.api.ctx().Done():
+			return resp, c.api.ctx().Err()
+		}
+		if backoff *= 2; backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func (c *HTTPClient) send(method, u string, headers map[string]string, body []byte) (*HTTPResponse, error) {
+	ctx := c.api.ctx()
+	req := &pb.PluginHTTPRequest{Method: method, Url: u, Headers: headers, Body: body}
+	if c.timeout > 0 {
+		req.TimeoutSeconds = int32((c.timeout + time.Second - 1) / time.Second)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout+5*time.Second)
+		defer cancel()
+	}
+
+	r, err := c.api.panel.HTTPRequest(ctx, req)
+	if err != nil {
+		return nil, &HTTPError{Method: method, URL: u, Err: err.Error()}
+	}
+	resp := &HTTPResponse{Status: int(r.Status), Headers: r.Headers, Body: r.Body, Error: r.Error}
+	if resp.Error != "" {
+		return resp, &HTTPError{Method: method, URL: u, Status: resp.Status, Body: resp.Body, Err: resp.Error}
+	}
+	if c.maxBody > 0 && len(resp.Body) > c.maxBody {
+		return resp, fmt.Errorf("%s %s: %w (%d > %d bytes)", method, u, ErrResponseTooLarge, len(resp.Body), c.maxBody)
+	}
+	if resp.Status >= 400 {
+		return resp, &HTTPError{Method: method, URL: u, Status: resp.Status, Body: resp.Body}
+	}
+	return resp, nil
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	var herr *HTTPError
+	if !errors.As(err, &herr) {
+		return false
+	}
+	if herr.Status == 0 {
+		return p.RetryTransport
+	}
+	if p.RetryStatus != nil {
+		return p.RetryStatus(herr.Status)
+	}
+	return herr.Status >= 500 || herr.Status == 429
+}
+
+func (c *HTTPClient) Get(path string, query url.Values) (*HTTPResponse, error) {
+	return c.Do("GET", path, query, nil, nil)
+}
+
+func (c *HTTPClient) Post(path string, contentType string, body []byte) (*HTTPResponse, error) {
+	return c.Do("POST", path, nil, map[string]string{"Content-Type": contentType}, body)
+}
+
+func (c *HTTPClient) Put(path string, contentType string, body []byte) (*HTTPResponse, error) {
+	return c.Do("PUT", path, nil, map[string]string{"Content-Type": contentType}, body)
+}
+
+func (c *HTTPClient) Delete(path string) (*HTTPResponse, error) {
+	return c.Do("DELETE", path, nil, nil, nil)
+}
+
+func GetJSON[T any](c *HTTPClient, path string, query url.Values) (T, error) {
+	var out T
+	resp, err := c.Do("GET", path, query, map[string]string{"Accept": "application/json"}, nil)
+	if err != nil {
+		return out, err
+	}
+	err = json.Unmarshal(resp.Body, &out)
+	return out, err
+}
+
+func PostJSON[T any](c *HTTPClient, path string, body interface{}) (T, error) {
+	return sendJSON[T](c, "POST", path, body)
+}
+
+func PutJSON[T any](c *HTTPClient, path string, body interface{}) (T, error) {
+	return sendJSON[T](c, "PUT", path, body)
+}
+
+func sendJSON[T any](c *HTTPClient, method, path string, body interface{}) (T, error) {
+	var out T
+	data, err := json.Marshal(body)
+	if err != nil {
+		return out, err
+	}
+	resp, err := c.Do(method, path, nil, map[string]string{"Content-Type": "application/json", "Accept": "application/json"}, data)
+	if err != nil {
+		return out, err
+	}
+	if len(resp.Body) == 0 {
+		return out, nil
+	}
+	err = json.Unmarshal(resp.Body, &out)
+	return out, err
+}
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const header = "// Code generated by internal/gen from proto/plugin.proto. DO NOT EDIT.\n\n"
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ip":   "IP",
+	"cpu":  "CPU",
+	"fqdn": "FQDN",
+	"url":  "URL",
+	"http": "HTTP",
+}
+
+type generator struct {
+	file   protoreflect.FileDescriptor
+	models map[protoreflect.FullName]model
+}
+
+func main() {
+	out := flag.String("out", ".", "output directory")
+	flag.Parse()
+
+	g := &generator{file: pb.File_plugin_proto, models: make(map[protoreflect.FullName]model)}
+	for _, m := range models {
+		md := g.message(m.Message)
+		if m.Name == "" {
+			m.Name = m.Message
+		}
+		for f := range m.Fields {
+			if md.Fields().ByName(protoreflect.Name(f)) == nil {
+				log.Fatalf("model %s: unknown field %s", m.Message, f)
+			}
+		}
+		g.models[md.FullName()] = m
+	}
+
+	files := map[string]func(*bytes.Buffer){
+		"models_gen.go": g.genModels,
+		"api_gen.go":    g.genAPI,
+		"async_gen.go":  g.genAsync,
+	}
+	for name, fn := range files {
+		var buf bytes.Buffer
+		fn(&buf)
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			log.Fatalf("%s: %v\n%s", name, err, buf.Bytes())
+		}
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func (g *generator) message(name string) protoreflect.MessageDescriptor {
+	md := g.file.Messages().ByName(protoreflect.Name(name))
+	if md == nil {
+		log.Fatalf("unknown message %s", name)
+	}
+	return md
+}
+
+func (g *generator) methods() []method {
+	svc := g.file.Services().ByName("PanelService")
+	byRPC := make(map[string][]method)
+	for _, m := range methods {
+		if svc.Methods().ByName(protoreflect.Name(m.RPC)) == nil {
+			log.Fatalf("unknown rpc %s", m.RPC)
+		}
+		byRPC[m.RPC] = append(byRPC[m.RPC], m)
+	}
+
+	var out []method
+	rpcs := svc.Methods()
+	for i := 0; i < rpcs.Len(); i++ {
+		rpc := rpcs.Get(i)
+		if rpc.IsStreamingClient() || rpc.IsStreamingServer() {
+			continue
+		}
+		specs, ok := byRPC[string(rpc.Name())]
+		if !ok {
+			specs = []method{{RPC: string(rpc.Name())}}
+		}
+		for _, m := range specs {
+			if m.Manual {
+				continue
+			}
+			if m.Name == "" {
+				m.Name = m.RPC
+			}
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (g *generator) genModels(w *bytes.Buffer) {
+	w.WriteString(header)
+	w.WriteString("package birdactyl\n\nimport pb \"github.com/pizzlad/birdactyl-go-sdk/proto\"\n\n")
+
+	for _, m := range models {
+		md := g.message(m.Message)
+		name := g.models[md.FullName()].Name
+		fields := md.Fields()
+
+		fmt.Fprintf(w, "type %s struct {\n", name)
+		for i := 0; i < fields.Len(); i++ {
+			f := fields.Get(i)
+			fmt.Fprintf(w, "\t%s %s\n", g.fieldName(md, f), goType(f))
+		}
+		w.WriteString("}\n\n")
+
+		fmt.Fprintf(w, "func %sFromProto(p *pb.%s) *%s {\n\treturn &%s{\n", lowerFirst(name), md.Name(), name, name)
+		for i := 0; i < fields.Len(); i++ {
+			f := fields.Get(i)
+			fmt.Fprintf(w, "\t\t%s: p.Get%s(),\n", g.fieldName(md, f), protoGoName(f))
+		}
+		w.WriteString("\t}\n}\n\n")
+
+		fmt.Fprintf(w, "func %sToProto(v *%s) *pb.%s {\n\treturn &pb.%s{\n", lowerFirst(name), name, md.Name(), md.Name())
+		for i := 0; i < fields.Len(); i++ {
+			f := fields.Get(i)
+			fmt.Fprintf(w, "\t\t%s: v.%s,\n", protoGoName(f), g.fieldName(md, f))
+		}
+		w.WriteString("\t}\n}\n\n")
+	}
+}
+
+func (g *generator) genAPI(w *bytes.Buffer) {
+	w.WriteString(header)
+	w.WriteString("package birdactyl\n\nimport pb \"github.com/pizzlad/birdactyl-go-sdk/proto\"\n\n")
+
+	for _, m := range g.methods() {
+		c := g.call(m)
+		result := c.result
+		switch {
+		case m.Legacy && result == "":
+			fmt.Fprintf(w, "func (a *API) %s(%s) {\n", m.Name, c.params)
+			w.WriteString(c.prelude)
+			fmt.Fprintf(w, "\ta.panel.%s(a.ctx(), req)\n}\n\n", m.RPC)
+		case m.Legacy:
+			fmt.Fprintf(w, "func (a *API) %s(%s) %s {\n", m.Name, c.params, result)
+			w.WriteString(c.prelude)
+			fmt.Fprintf(w, "\tr, _ := a.panel.%s(a.ctx(), req)\n\treturn %s\n}\n\n", m.RPC, c.convert)
+		case result == "":
+			fmt.Fprintf(w, "func (a *API) %s(%s) error {\n", m.Name, c.params)
+			w.WriteString(c.prelude)
+			fmt.Fprintf(w, "\t_, err := a.panel.%s(a.ctx(), req)\n\treturn err\n}\n\n", m.RPC)
+		default:
+			fmt.Fprintf(w, "func (a *API) %s(%s) (%s, error) {\n", m.Name, c.params, result)
+			w.WriteString(c.prelude)
+			fmt.Fprintf(w, "\tr, err := a.panel.%s(a.ctx(), req)\n\tif err != nil {\n\t\treturn %s, err\n\t}\n\treturn %s, nil\n}\n\n", m.RPC, zero(result), c.convert)
+		}
+	}
+}
+
+func (g *generator) genAsync(w *bytes.Buffer) {
+	w.WriteString(header)
+	w.WriteString("package birdactyl\n\nimport (\n\t\"context\"\n\n\tpb \"github.com/pizzlad/birdactyl-go-sdk/proto\"\n)\n\n")
+
+	for _, m := range g.methods() {
+		c := g.call(m)
+		result := c.result
+		if result == "" {
+			fmt.Fprintf(w, "func (a *AsyncAPI) %s(%s) *Future[struct{}] {\n", m.Name, c.params)
+			w.WriteString(c.prelude)
+			fmt.Fprintf(w, "\treturn submit(a, %q, func(ctx context.Context) (struct{}, error) {\n", m.Name)
+			fmt.Fprintf(w, "\t\t_, err := a.panel.%s(ctx, req)\n\t\treturn struct{}{}, err\n\t})\n}\n\n", m.RPC)
+			continue
+		}
+		fmt.Fprintf(w, "func (a *AsyncAPI) %s(%s) *Future[%s] {\n", m.Name, c.params, result)
+		w.WriteString(c.prelude)
+		fmt.Fprintf(w, "\treturn submit(a, %q, func(ctx context.Context) (%s, error) {\n", m.Name, result)
+		fmt.Fprintf(w, "\t\tr, err := a.panel.%s(ctx, req)\n\t\tif err != nil {\n\t\t\treturn %s, err\n\t\t}\n\t\treturn %s, nil\n\t})\n}\n\n", m.RPC, zero(result), c.convert)
+	}
+}
+
+type call struct {
+	params  string
+	prelude string
+	result  string
+	convert string
+}
+
+func (g *generator) call(m method) call {
+	rpc := g.file.Services().ByName("PanelService").Methods().ByName(protoreflect.Name(m.RPC))
+	in, out := rpc.Input(), rpc.Output()
+
+	var fields []protoreflect.FieldDescriptor
+	if m.Params == nil {
+		for i := 0; i < in.Fields().Len(); i++ {
+			fields = append(fields, in.Fields().Get(i))
+		}
+	} else {
+		for _, name := range m.Params {
+			f := in.Fields().ByName(protoreflect.Name(name))
+			if f == nil {
+				log.Fatalf("%s: unknown request field %s", m.Name, name)
+			}
+			fields = append(fields, f)
+		}
+	}
+
+	var c call
+	var params, assign, optional []string
+	for i, f := range fields {
+		name := paramName(f)
+		if r, ok := m.Rename[string(f.Name())]; ok {
+			name = r
+		}
+		typ := goType(f)
+		if m.Optional && i > 0 {
+			typ = "*" + typ
+			optional = append(optional, fmt.Sprintf("\tif %s != nil {\n\t\treq.%s = *%s\n\t}\n", name, protoGoName(f), name))
+		} else {
+			assign = append(assign, fmt.Sprintf("%s: %s", protoGoName(f), name))
+		}
+		params = append(params, name+" "+typ)
+	}
+	c.params = joinParams(params)
+	c.prelude = fmt.Sprintf("\treq := &pb.%s{%s}\n%s", in.Name(), strings.Join(assign, ", "), strings.Join(optional, ""))
+
+	if m.Result == "" {
+		if out.Fields().Len() > 0 {
+			mod, ok := g.models[out.FullName()]
+			if !ok {
+				log.Fatalf("%s: %s is not a model; set Result or mark the method Manual", m.Name, out.Name())
+			}
+			c.result = "*" + mod.Name
+			c.convert = lowerFirst(mod.Name) + "FromProto(r)"
+		}
+		return c
+	}
+
+	f := out.Fields().ByName(protoreflect.Name(m.Result))
+	if f == nil {
+		log.Fatalf("%s: unknown response field %s", m.Name, m.Result)
+	}
+	getter := "r.Get" + protoGoName(f) + "()"
+	switch {
+	case f.Message() != nil && f.IsList():
+		mod, ok := g.models[f.Message().FullName()]
+		if !ok {
+			log.Fatalf("%s: %s is not a model", m.Name, f.Message().Name())
+		}
+		c.result = "[]*" + mod.Name
+		c.convert = fmt.Sprintf("convertAll(%s, %sFromProto)", getter, lowerFirst(mod.Name))
+	case f.Message() != nil:
+		mod, ok := g.models[f.Message().FullName()]
+		if !ok {
+			log.Fatalf("%s: %s is not a model", m.Name, f.Message().Name())
+		}
+		c.result = "*" + mod.Name
+		c.convert = fmt.Sprintf("%sFromProto(%s)", lowerFirst(mod.Name), getter)
+	default:
+		c.result = goType(f)
+		c.convert = getter
+	}
+	return c
+}
+
+func joinParams(params []string) string {
+	out := make([]string, len(params))
+	for i, p := range params {
+		name, typ, _ := strings.Cut(p, " ")
+		if i+1 < len(params) {
+			if _, next, _ := strings.Cut(params[i+1], " "); next == typ {
+				out[i] = name
+				continue
+			}
+		}
+		out[i] = name + " " + typ
+	}
+	return strings.Join(out, ", ")
+}
+
+func (g *generator) fieldName(md protoreflect.MessageDescriptor, f protoreflect.FieldDescriptor) string {
+	if name, ok := g.models[md.FullName()].Fields[string(f.Name())]; ok {
+		return name
+	}
+	return camel(string(f.Name()), true)
+}
+
+func protoGoName(f protoreflect.FieldDescriptor) string {
+	parts := strings.Split(string(f.Name()), "_")
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func paramName(f protoreflect.FieldDescriptor) string {
+	name := camel(string(f.Name()), false)
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+	return name
+}
+
+func camel(s string, exported bool) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if i == 0 && !exported {
+			continue
+		}
+		if up, ok := initialisms[p]; ok {
+			parts[i] = up
+		} else {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func lowerFirst(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= 'A' && s[i] <= 'Z' {
+		i++
+	}
+	switch {
+	case i == 0:
+		return s
+	case i == 1 || i == len(s):
+		return strings.ToLower(s[:i]) + s[i:]
+	default:
+		return strings.ToLower(s[:i-1]) + s[i-1:]
+	}
+}
+
+func goType(f protoreflect.FieldDescriptor) string {
+	if f.IsMap() {
+		return "map[" + goType(f.MapKey()) + "]" + goType(f.MapValue())
+	}
+	var t string
+	switch f.Kind() {
+	case protoreflect.StringKind:
+		t = "string"
+	case protoreflect.BoolKind:
+		t = "bool"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		t = "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		t = "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		t = "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		t = "uint64"
+	case protoreflect.DoubleKind:
+		t = "float64"
+	case protoreflect.FloatKind:
+		t = "float32"
+	case protoreflect.BytesKind:
+		t = "[]byte"
+	default:
+		log.Fatalf("unsupported field %s of kind %s", f.FullName(), f.Kind())
+	}
+	if f.IsList() {
+		return "[]" + t
+	}
+	return t
+}
+
+func zero(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	default:
+		return "0"
+	}
+}
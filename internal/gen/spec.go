@@ -0,0 +1,79 @@
+package main
+
+type model struct {
+	Message string
+	Name    string
+	Fields  map[string]string
+}
+
+type method struct {
+	RPC      string
+	Name     string
+	Params   []string
+	Rename   map[string]string
+	Optional bool
+	Result   string
+	Legacy   bool
+	Manual   bool
+}
+
+var models = []model{
+	{Message: "Server", Fields: map[string]string{"user_id": "OwnerID"}},
+	{Message: "ServerStats"},
+	{Message: "LogMatch"},
+	{Message: "LogFileInfo", Name: "LogFile"},
+	{Message: "User", Fields: map[string]string{"cpu_limit": "CpuLimit"}},
+	{Message: "Subuser"},
+	{Message: "Database"},
+	{Message: "DatabaseHost"},
+	{Message: "FileInfo", Name: "File", Fields: map[string]string{"modified": "ModTime"}},
+	{Message: "Backup"},
+	{Message: "Node"},
+	{Message: "Package", Fields: map[string]string{"default_memory": "Memory", "default_cpu": "CPU", "default_disk": "Disk"}},
+	{Message: "IPBan"},
+	{Message: "Settings"},
+	{Message: "ActivityLog"},
+	{Message: "UploadStatus"},
+}
+
+var methods = []method{
+	{RPC: "ListServers", Params: []string{}, Result: "servers", Legacy: true},
+	{RPC: "ListServers", Name: "ListServersByUser", Params: []string{"user_id"}, Result: "servers", Legacy: true},
+	{RPC: "UpdateServer", Params: []string{"id", "name", "memory", "cpu", "disk"}, Optional: true},
+	{RPC: "GetServerStats", Rename: map[string]string{"id": "serverID"}},
+	{RPC: "GetConsoleLog", Result: "lines"},
+	{RPC: "GetFullLog", Rename: map[string]string{"id": "serverID"}, Result: "content"},
+	{RPC: "SearchLogs", Params: []string{"server_id", "pattern", "regex", "limit"}, Result: "matches"},
+	{RPC: "ListLogFiles", Rename: map[string]string{"id": "serverID"}, Result: "files"},
+	{RPC: "ReadLogFile", Result: "content"},
+	{RPC: "ListUsers", Params: []string{}, Result: "users", Legacy: true},
+	{RPC: "UpdateUser", Params: []string{"id", "username", "email"}, Optional: true},
+	{RPC: "SetUserResources", Optional: true},
+	{RPC: "ListSubusers", Rename: map[string]string{"id": "serverID"}, Result: "subusers", Legacy: true},
+	{RPC: "ListDatabases", Rename: map[string]string{"id": "serverID"}, Result: "databases", Legacy: true},
+	{RPC: "CreateDatabase", Params: []string{"server_id", "name"}},
+	{RPC: "ListDatabaseHosts", Result: "hosts", Legacy: true},
+	{RPC: "UpdateDatabaseHost", Optional: true},
+	{RPC: "ListFiles", Result: "files", Legacy: true},
+	{RPC: "ReadFile", Result: "content"},
+	{RPC: "ListBackups", Rename: map[string]string{"id": "serverID"}, Result: "backups", Legacy: true},
+	{RPC: "ListNodes", Result: "nodes", Legacy: true},
+	{RPC: "CreateNode", Manual: true},
+	{RPC: "ResetNodeToken", Result: "token"},
+	{RPC: "ListPackages", Result: "packages", Legacy: true},
+	{RPC: "UpdatePackage", Params: []string{"id", "name", "description", "default_memory", "default_cpu", "default_disk"}, Optional: true},
+	{RPC: "ListIPBans", Result: "bans", Legacy: true},
+	{RPC: "GetSettings", Legacy: true},
+	{RPC: "SetRegistrationEnabled", Rename: map[string]string{"value": "enabled"}},
+	{RPC: "SetServerCreationEnabled", Rename: map[string]string{"value": "enabled"}},
+	{RPC: "GetActivityLogs", Params: []string{"limit"}, Result: "logs", Legacy: true},
+	{RPC: "Log", Legacy: true},
+	{RPC: "GetKV", Manual: true},
+	{RPC: "SetKV", Legacy: true},
+	{RPC: "DeleteKV", Legacy: true},
+	{RPC: "QueryDB", Manual: true},
+	{RPC: "BroadcastEvent", Legacy: true},
+	{RPC: "SendNotification", Manual: true},
+	{RPC: "HTTPRequest", Manual: true},
+	{RPC: "CallPlugin", Manual: true},
+}
@@ -0,0 +1,404 @@
+package birdactyl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+var ErrKVConflict = errors.New("birdactyl: kv value changed concurrently")
+
+const (
+	kvIndexKey    = "__kv_index__"
+	kvIndexShards = 16
+	kvIndexTries  = 3
+)
+
+type KV[T any] struct {
+	api *API
+	ns  string
+}
+
+type kvEnvelope struct {
+	Value   json.RawMessage `json:"v"`
+	Expires int64           `json:"exp,omitempty"`
+	Version uint64          `json:"ver"`
+}
+
+// kvLocks serializes read-modify-write cycles within this process only. The
+// panel KV has no conditional write, so Update, CompareAndSwap and Increment
+// are not atomic across plugin instances sharing the same keys.
+var kvLocks sync.Map
+
+func kvLock(key string) *sync.Mutex {
+	mu, _ := kvLocks.LoadOrStore(key, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+func NewKV[T any](api *API, namespace string) *KV[T] {
+	return &KV[T]{api: api, ns: strings.Trim(namespace, "/")}
+}
+
+func (k *KV[T]) Namespace(sub string) *KV[T] {
+	return &KV[T]{api: k.api, ns: k.key(strings.Trim(sub, "/"))}
+}
+
+func (k *KV[T]) key(key string) string {
+	if k.ns == "" {
+		return key
+	}
+	return k.ns + "/" + key
+}
+
+func kvCheckKey(key string) error {
+	if key == "" || strings.HasPrefix(key, kvIndexKey) {
+		return fmt.Errorf("kv key %q is reserved", key)
+	}
+	if strings.Contains(key, "/") {
+		return fmt.Errorf("kv key %q must not contain /", key)
+	}
+	return nil
+}
+
+func (k *KV[T]) raw(full string) (*kvEnvelope, error) {
+	env, err := k.rawAny(full)
+	if env == nil || err != nil || env.expired() {
+		return nil, err
+	}
+	return env, nil
+}
+
+func (e *kvEnvelope) expired() bool {
+	return e.Expires > 0 && time.Now().UnixMilli() >= e.Expires
+}
+
+func (k *KV[T]) rawAny(full string) (*kvEnvelope, error) {
+	r, err := k.api.panel.GetKV(k.api.ctx(), &pb.KVRequest{Key: full})
+	if err != nil {
+		return nil, err
+	}
+	if !r.GetFound() {
+		return nil, nil
+	}
+	var env kvEnvelope
+	if err := json.Unmarshal([]byte(r.GetValue()), &env); err != nil || env.Value == nil {
+		env = kvEnvelope{Value: json.RawMessage(r.GetValue())}
+		if !json.Valid(env.Value) {
+			env.Value, _ = json.Marshal(r.GetValue())
+		}
+	}
+	return &env, nil
+}
+
+func (k *KV[T]) write(full string, env *kvEnvelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	_, err = k.api.panel.SetKV(k.api.ctx(), &pb.KVSetRequest{Key: full, Value: string(data)})
+	return err
+}
+
+func (k *KV[T]) Get(key string) (T, bool, error) {
+	v, _, ok, err := k.GetVersion(key)
+	return v, ok, err
+}
+
+func (k *KV[T]) GetVersion(key string) (T, uint64, bool, error) {
+	var out T
+	if err := kvCheckKey(key); err != nil {
+		return out, 0, false, err
+	}
+	env, err := k.raw(k.key(key))
+	if err != nil || env == nil {
+		return out, 0, false, err
+	}
+	if err := json.Unmarshal(env.Value, &out); err != nil {
+		return out, 0, false, err
+	}
+	return out, env.Version, true, nil
+}
+
+func (k *KV[T]) Set(key string, v T) error {
+	return k.SetTTL(key, v, 0)
+}
+
+func (k *KV[T]) SetTTL(key string, v T, ttl time.Duration) error {
+	_, err := k.update(key, ttl, nil, func(T, bool) (T, error) { return v, nil })
+	return err
+}
+
+func (k *KV[T]) Update(key string, fn func(current T, found bool) (T, error)) (T, error) {
+	return k.update(key, -1, nil, fn)
+}
+
+func (k *KV[T]) CompareAndSwap(key string, version uint64, v T) (bool, error) {
+	_, err := k.update(key, -1, &version, func(T, bool) (T, error) { return v, nil })
+	if errors.Is(err, ErrKVConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (k *KV[T]) update(key string, ttl time.Duration, expect *uint64, fn func(T, bool) (T, error)) (T, error) {
+	var cur T
+	if err := kvCheckKey(key); err != nil {
+		return cur, err
+	}
+	full := k.key(key)
+	mu := kvLock(full)
+	mu.Lock()
+	defer mu.Unlock()
+
+	env, err := k.rawAny(full)
+	if err != nil {
+		return cur, err
+	}
+	var version uint64
+	var expires int64
+	if env != nil {
+		version = env.Version
+	}
+	found := env != nil && !env.expired()
+	if found {
+		expires = env.Expires
+		if err := json.Unmarshal(env.Value, &cur); err != nil {
+			return cur, err
+		}
+	}
+	if expect != nil && *expect != version {
+		return cur, ErrKVConflict
+	}
+
+	next, err := fn(cur, found)
+	if err != nil {
+		return cur, err
+	}
+	data, err := json.Marshal(next)
+	if err != nil {
+		return cur, err
+	}
+	out := &kvEnvelope{Value: data, Version: version + 1}
+	switch {
+	case ttl > 0:
+		out.Expires = time.Now().Add(ttl).UnixMilli()
+	case ttl < 0:
+		out.Expires = expires
+	}
+	if err := k.write(full, out); err != nil {
+		return cur, err
+	}
+	if env == nil {
+		if err := k.index(key, true); err != nil {
+			return next, err
+		}
+	}
+	return next, nil
+}
+
+func (k *KV[T]) Delete(key string) error {
+	if err := kvCheckKey(key); err != nil {
+		return err
+	}
+	full := k.key(key)
+	mu := kvLock(full)
+	mu.Lock()
+	defer mu.Unlock()
+	if _, err := k.api.panel.DeleteKV(k.api.ctx(), &pb.KVRequest{Key: full}); err != nil {
+		return err
+	}
+	return k.index(key, false)
+}
+
+func (k *KV[T]) shardKey(key string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return k.key(fmt.Sprintf("%s/%02x", kvIndexKey, h.Sum32()%kvIndexShards))
+}
+
+func (k *KV[T]) shard(full string) ([]string, error) {
+	r, err := k.api.panel.GetKV(k.api.ctx(), &pb.KVRequest{Key: full})
+	if err != nil || !r.GetFound() {
+		return nil, err
+	}
+	var keys []string
+	err = json.Unmarshal([]byte(r.GetValue()), &keys)
+	return keys, err
+}
+
+func (k *KV[T]) index(key string, add bool) error {
+	if err := k.migrateIndex(); err != nil {
+		return err
+	}
+	full := k.shardKey(key)
+	mu := kvLock(full)
+	mu.Lock()
+	defer mu.Unlock()
+	for try := 0; ; try++ {
+		keys, err := k.shard(full)
+		if err != nil {
+			return err
+		}
+		i := sort.SearchStrings(keys, key)
+		present := i < len(keys) && keys[i] == key
+		if present == add {
+			return nil
+		}
+		if try == kvIndexTries {
+			return ErrKVConflict
+		}
+		if add {
+			keys = append(keys[:i], append([]string{key}, keys[i:]...)...)
+		} else {
+			keys = append(keys[:i], keys[i+1:]...)
+		}
+		data, err := json.Marshal(keys)
+		if err != nil {
+			return err
+		}
+		if _, err := k.api.panel.SetKV(k.api.ctx(), &pb.KVSetRequest{Key: full, Value: string(data)}); err != nil {
+			return err
+		}
+	}
+}
+
+func (k *KV[T]) migrateIndex() error {
+	full := k.key(kvIndexKey)
+	mu := kvLock(full)
+	mu.Lock()
+	defer mu.Unlock()
+	keys, err := k.shard(full)
+	if err != nil || keys == nil {
+		return err
+	}
+	shards := make(map[string][]string)
+	for _, key := range keys {
+		shards[k.shardKey(key)] = append(shards[k.shardKey(key)], key)
+	}
+	for sk, add := range shards {
+		cur, err := k.shard(sk)
+		if err != nil {
+			return err
+		}
+		set := make(map[string]bool, len(cur)+len(add))
+		for _, key := range append(cur, add...) {
+			set[key] = true
+		}
+		merged := make([]string, 0, len(set))
+		for key := range set {
+			merged = append(merged, key)
+		}
+		sort.Strings(merged)
+		data, err := json.Marshal(merged)
+		if err != nil {
+			return err
+		}
+		if _, err := k.api.panel.SetKV(k.api.ctx(), &pb.KVSetRequest{Key: sk, Value: string(data)}); err != nil {
+			return err
+		}
+	}
+	_, err = k.api.panel.DeleteKV(k.api.ctx(), &pb.KVRequest{Key: full})
+	return err
+}
+
+func (k *KV[T]) indexKeys() ([]string, error) {
+	if err := k.migrateIndex(); err != nil {
+		return nil, err
+	}
+	var keys []string
+	for i := 0; i < kvIndexShards; i++ {
+		shard, err := k.shard(k.key(fmt.Sprintf("%s/%02x", kvIndexKey, i)))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, shard...)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (k *KV[T]) Keys(prefix string) ([]string, error) {
+	keys, err := k.indexKeys()
+	if err != nil {
+		return nil, err
+	}
+	out := keys[:0]
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			out = append(out, key)
+		}
+	}
+	return out, nil
+}
+
+func (k *KV[T]) Scan(prefix string) (map[string]T, error) {
+	keys, err := k.Keys(prefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]T, len(keys))
+	var expired []string
+	for _, key := range keys {
+		v, ok, err := k.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			expired = append(expired, key)
+			continue
+		}
+		out[key] = v
+	}
+	for _, key := range expired {
+		if _, err := k.deleteExpired(key); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func (k *KV[T]) Purge() (int, error) {
+	keys, err := k.Keys("")
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, key := range keys {
+		removed, err := k.deleteExpired(key)
+		if err != nil {
+			return n, err
+		}
+		if removed {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (k *KV[T]) deleteExpired(key string) (bool, error) {
+	full := k.key(key)
+	mu := kvLock(full)
+	mu.Lock()
+	defer mu.Unlock()
+	env, err := k.raw(full)
+	if err != nil || env != nil {
+		return false, err
+	}
+	if _, err := k.api.panel.DeleteKV(k.api.ctx(), &pb.KVRequest{Key: full}); err != nil {
+		return false, err
+	}
+	return true, k.index(key, false)
+}
+
+func Increment(k *KV[int64], key string, delta int64) (int64, error) {
+	return k.Update(key, func(cur int64, _ bool) (int64, error) {
+		return cur + delta, nil
+	})
+}
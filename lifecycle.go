@@ -0,0 +1,151 @@
+package birdactyl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	ServerStateRunning  = "running"
+	ServerStateStarting = "starting"
+	ServerStateStopping = "stopping"
+	ServerStateOffline  = "offline"
+)
+
+var ErrServerCrashed = errors.New("birdactyl: server went offline while starting")
+
+var LifecyclePollInterval = 2 * time.Second
+
+var lifecycleEvents = []string{"server.start", "server.stop", "server.restart", "server.kill", "server.status"}
+
+type StopPolicy struct {
+	Command   string
+	StopAfter time.Duration
+	KillAfter time.Duration
+	Timeout   time.Duration
+}
+
+var DefaultStopPolicy = StopPolicy{KillAfter: 30 * time.Second, Timeout: 60 * time.Second}
+
+func (p *Plugin) UseLifecycleEvents() *Plugin {
+	p.bus.declare(lifecycleEvents...)
+	return p
+}
+
+func (a *API) WaitForState(ctx context.Context, serverID string, states ...string) (*ServerStats, error) {
+	return a.waitForState(ctx, serverID, states, nil)
+}
+
+func (a *API) waitForState(ctx context.Context, serverID string, states []string, check func(*ServerStats) error) (*ServerStats, error) {
+	wake := make(chan struct{}, 1)
+	if a.events != nil {
+		notify := func(ev Event) {
+			if ev.Data["server_id"] == serverID || ev.Data["id"] == serverID {
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			}
+		}
+		for _, t := range lifecycleEvents {
+			defer a.events.subscribe(t, notify)()
+		}
+	}
+
+	api := a.WithContext(ctx)
+	ticker := time.NewTicker(LifecyclePollInterval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := api.GetServerStats(serverID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		for _, s := range states {
+			if stats.State == s {
+				return stats, nil
+			}
+		}
+		if check != nil {
+			if err := check(stats); err != nil {
+				return stats, err
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-wake:
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		}
+	}
+}
+
+func (a *API) StartAndWait(ctx context.Context, serverID string) (*ServerStats, error) {
+	if err := a.WithContext(ctx).StartServer(serverID); err != nil {
+		return nil, err
+	}
+	seenStarting := false
+	return a.waitForState(ctx, serverID, []string{ServerStateRunning}, func(s *ServerStats) error {
+		switch s.State {
+		case ServerStateStarting:
+			seenStarting = true
+		case ServerStateOffline:
+			if seenStarting {
+				return ErrServerCrashed
+			}
+		}
+		return nil
+	})
+}
+
+func (a *API) StopAndWait(ctx context.Context, serverID string, policy StopPolicy) (*ServerStats, error) {
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+	api := a.WithContext(ctx)
+
+	if policy.Command != "" {
+		if err := api.SendCommand(serverID, policy.Command); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := api.StopServer(serverID); err != nil {
+			return nil, err
+		}
+	}
+
+	began := time.Now()
+	stopped := policy.Command == ""
+	killed := false
+	return a.waitForState(ctx, serverID, []string{ServerStateOffline}, func(*ServerStats) error {
+		elapsed := time.Since(began)
+		if !stopped && policy.StopAfter > 0 && elapsed >= policy.StopAfter {
+			stopped = true
+			if err := api.StopServer(serverID); err != nil {
+				return fmt.Errorf("stop after %s: %w", policy.StopAfter, err)
+			}
+		}
+		if !killed && policy.KillAfter > 0 && elapsed >= policy.KillAfter {
+			killed = true
+			if err := api.KillServer(serverID); err != nil {
+				return fmt.Errorf("kill after %s: %w", policy.KillAfter, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (a *API) RestartAndWait(ctx context.Context, serverID string, policy StopPolicy) (*ServerStats, error) {
+	if _, err := a.StopAndWait(ctx, serverID, policy); err != nil {
+		return nil, err
+	}
+	return a.StartAndWait(ctx, serverID)
+}
@@ -0,0 +1,90 @@
+package birdactyl
+
+import "regexp"
+
+type LogEntry struct {
+	Time    string
+	Level   string
+	Source  string
+	Message string
+	Raw     string
+}
+
+type LogParser interface {
+	Parse(line string) (LogEntry, bool)
+}
+
+type LogParserFunc func(line string) (LogEntry, bool)
+
+func (f LogParserFunc) Parse(line string) (LogEntry, bool) {
+	return f(line)
+}
+
+type regexLogParser struct {
+	re *regexp.Regexp
+}
+
+func RegexLogParser(pattern string) (LogParser, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexLogParser{re: re}, nil
+}
+
+func mustRegexLogParser(pattern string) LogParser {
+	return regexLogParser{re: regexp.MustCompile(pattern)}
+}
+
+func (p regexLogParser) Parse(line string) (LogEntry, bool) {
+	m := p.re.FindStringSubmatch(line)
+	if m == nil {
+		return LogEntry{}, false
+	}
+	entry := LogEntry{Raw: line, Message: line}
+	for i, name := range p.re.SubexpNames() {
+		switch name {
+		case "time":
+			entry.Time = m[i]
+		case "level":
+			entry.Level = m[i]
+		case "source":
+			entry.Source = m[i]
+		case "message":
+			entry.Message = m[i]
+		}
+	}
+	return entry, true
+}
+
+type multiLogParser []LogParser
+
+func FirstLogParser(parsers ...LogParser) LogParser {
+	return multiLogParser(parsers)
+}
+
+func (m multiLogParser) Parse(line string) (LogEntry, bool) {
+	for _, p := range m {
+		if entry, ok := p.Parse(line); ok {
+			return entry, true
+		}
+	}
+	return LogEntry{}, false
+}
+
+var (
+	MinecraftLogParser = FirstLogParser(
+		mustRegexLogParser(`^\[(?P<time>\d{2}:\d{2}:\d{2})\] \[(?P<source>[^/\]]+)/(?P<level>[A-Z]+)\](?: \[[^\]]*\])?: (?P<message>.*)$`),
+		mustRegexLogParser(`^\[(?P<time>\d{2}:\d{2}:\d{2}) (?P<level>[A-Z]+)\]: (?:\[(?P<source>[^\]]+)\] )?(?P<message>.*)$`),
+	)
+	SourceLogParser  = mustRegexLogParser(`^L (?P<time>\d{2}/\d{2}/\d{4} - \d{2}:\d{2}:\d{2}): (?P<message>.*)$`)
+	Log4jLogParser   = mustRegexLogParser(`^(?P<time>\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?)\s+(?P<level>TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\s+(?:\[(?P<source>[^\]]+)\]\s+)?(?P<message>.*)$`)
+	BracketLogParser = mustRegexLogParser(`^\[(?P<time>[^\]]+)\]\s*\[?(?P<level>TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\]?:?\s+(?P<message>.*)$`)
+	PlainLogParser   = LogParserFunc(func(line string) (LogEntry, bool) { return LogEntry{Message: line, Raw: line}, true })
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07]*\x07`)
+
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,360 @@
+package birdactyl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrMigrationLocked = errors.New("birdactyl: migrations are locked by another instance")
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	api         *API
+	migrations  []Migration
+	versions    string
+	lock        string
+	owner       string
+	LockTimeout time.Duration
+	LockTTL     time.Duration
+}
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+?)(?:\.(up|down))?\.sql$`)
+
+var dollarTagRe = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "down" {
+			mig.Down = string(data)
+		} else {
+			mig.Up = string(data)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", mig.Version, mig.Name)
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+func NewMigrator(api *API, migrations []Migration) *Migrator {
+	return &Migrator{
+		api:         api,
+		migrations:  migrations,
+		versions:    api.Table("schema_migrations"),
+		lock:        api.Table("schema_lock"),
+		owner:       randomID(),
+		LockTimeout: 30 * time.Second,
+		LockTTL:     5 * time.Minute,
+	}
+}
+
+func (p *Plugin) Migrations(fsys fs.FS, dir string) *Plugin {
+	migrations, err := LoadMigrations(fsys, dir)
+	if err != nil {
+		return p.setupError(fmt.Errorf("migrations %s: %w", dir, err))
+	}
+	p.migrations = migrations
+	return p
+}
+
+func (m *Migrator) init() error {
+	if _, err := m.api.ExecDB("CREATE TABLE IF NOT EXISTS " + m.versions + " (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at BIGINT NOT NULL)"); err != nil {
+		return err
+	}
+	_, err := m.api.ExecDB("CREATE TABLE IF NOT EXISTS " + m.lock + " (id INTEGER PRIMARY KEY, owner VARCHAR(64) NOT NULL, expires_at BIGINT NOT NULL)")
+	return err
+}
+
+func (m *Migrator) acquire() error {
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		now := time.Now()
+		_, err := m.api.ExecDB("INSERT INTO "+m.lock+" (id, owner, expires_at) VALUES (1, ?, ?)", m.owner, now.Add(m.LockTTL).UnixMilli())
+		if err == nil {
+			return nil
+		}
+		if !isConstraintError(err) {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if _, err := m.api.ExecDB("DELETE FROM "+m.lock+" WHERE id = 1 AND expires_at < ?", now.UnixMilli()); err != nil {
+			return err
+		}
+		if now.After(deadline) {
+			return ErrMigrationLocked
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func isConstraintError(err error) bool {
+	if status.Code(err) == codes.AlreadyExists {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique") || strings.Contains(msg, "duplicate") || strings.Contains(msg, "constraint")
+}
+
+func (m *Migrator) renew() error {
+	res, err := m.api.ExecDB("UPDATE "+m.lock+" SET expires_at = ? WHERE id = 1 AND owner = ?", time.Now().Add(m.LockTTL).UnixMilli(), m.owner)
+	if err != nil {
+		return fmt.Errorf("renew migration lock: %w", err)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("renew migration lock: %w", ErrMigrationLocked)
+	}
+	return nil
+}
+
+func (m *Migrator) release() {
+	if _, err := m.api.ExecDB("DELETE FROM "+m.lock+" WHERE id = 1 AND owner = ?", m.owner); err != nil {
+		log.Printf("[migrate] failed to release lock: %v", err)
+	}
+}
+
+func (m *Migrator) Applied() ([]int, error) {
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+	return m.applied()
+}
+
+func (m *Migrator) applied() ([]int, error) {
+	versions, err := QueryInto[int](m.api, "SELECT version FROM "+m.versions+" ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.Applied()
+	if err != nil {
+		return nil, err
+	}
+	return m.pending(applied)
+}
+
+func (m *Migrator) pending(applied []int) ([]Migration, error) {
+	done := make(map[int]bool, len(applied))
+	latest := -1
+	for _, v := range applied {
+		done[v] = true
+		latest = max(latest, v)
+	}
+	var out []Migration
+	for _, mig := range m.migrations {
+		if done[mig.Version] {
+			continue
+		}
+		if mig.Version < latest {
+			return nil, fmt.Errorf("migration %d_%s is pending but older than applied version %d", mig.Version, mig.Name, latest)
+		}
+		out = append(out, mig)
+	}
+	return out, nil
+}
+
+func (m *Migrator) Up() ([]int, error) {
+	return m.To(-1)
+}
+
+func (m *Migrator) To(target int) ([]int, error) {
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+	if err := m.acquire(); err != nil {
+		return nil, err
+	}
+	defer m.release()
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := m.pending(applied)
+	if err != nil {
+		return nil, err
+	}
+	var done []int
+	for _, mig := range pending {
+		if target >= 0 && mig.Version > target {
+			break
+		}
+		if err := m.run(mig.Up); err != nil {
+			return done, fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := m.api.ExecDB("INSERT INTO "+m.versions+" (version, name, applied_at) VALUES (?, ?, ?)", mig.Version, mig.Name, time.Now().UnixMilli()); err != nil {
+			return done, fmt.Errorf("record migration %d: %w", mig.Version, err)
+		}
+		done = append(done, mig.Version)
+	}
+	if target >= 0 {
+		reverted, err := m.down(applied, target)
+		return append(done, reverted...), err
+	}
+	return done, nil
+}
+
+func (m *Migrator) Down(steps int) ([]int, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("migrate down: steps must be positive, got %d", steps)
+	}
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+	if err := m.acquire(); err != nil {
+		return nil, err
+	}
+	defer m.release()
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	target := 0
+	if steps < len(applied) {
+		target = applied[len(applied)-steps-1]
+	}
+	return m.down(applied, target)
+}
+
+func (m *Migrator) down(applied []int, target int) ([]int, error) {
+	byVersion := make(map[int]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+	var done []int
+	for i := len(applied) - 1; i >= 0 && applied[i] > target; i-- {
+		mig, ok := byVersion[applied[i]]
+		if !ok {
+			return done, fmt.Errorf("migration %d is applied but not known to this plugin", applied[i])
+		}
+		if strings.TrimSpace(mig.Down) == "" {
+			return done, fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+		}
+		if err := m.run(mig.Down); err != nil {
+			return done, fmt.Errorf("revert %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := m.api.ExecDB("DELETE FROM "+m.versions+" WHERE version = ?", mig.Version); err != nil {
+			return done, fmt.Errorf("unrecord migration %d: %w", mig.Version, err)
+		}
+		done = append(done, mig.Version)
+	}
+	return done, nil
+}
+
+func (m *Migrator) run(script string) error {
+	for _, stmt := range splitSQL(script) {
+		if err := m.renew(); err != nil {
+			return err
+		}
+		if _, err := m.api.ExecDB(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitSQL(script string) []string {
+	var out []string
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+			if c == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				b.WriteByte(script[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '$':
+			if tag := dollarTagRe.FindString(script[i:]); tag != "" {
+				end := strings.Index(script[i+len(tag):], tag)
+				if end < 0 {
+					end = len(script) - i - len(tag)
+				} else {
+					end += len(tag)
+				}
+				b.WriteString(script[i : i+len(tag)+end])
+				i += len(tag) + end - 1
+				continue
+			}
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			b.WriteByte(' ')
+			continue
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+			continue
+		case c == ';':
+			if s := strings.TrimSpace(b.String()); s != "" {
+				out = append(out, s)
+			}
+			b.Reset()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	if s := strings.TrimSpace(b.String()); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
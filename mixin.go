@@ -30,9 +30,11 @@ const (
 	MixinDatabaseDelete = "database.delete"
 	MixinDatabaseList   = "database.list"
 
-	MixinBackupCreate = "backup.create"
-	MixinBackupDelete = "backup.delete"
-	MixinBackupList   = "backup.list"
+	MixinBackupCreate   = "backup.create"
+	MixinBackupDelete   = "backup.delete"
+	MixinBackupList     = "backup.list"
+	MixinBackupRestore  = "backup.restore"
+	MixinBackupDownload = "backup.download"
 
 	MixinFileRead       = "file.read"
 	MixinFileWrite      = "file.write"
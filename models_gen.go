@@ -0,0 +1,540 @@
+// Code generated by internal/gen from proto/plugin.proto. DO NOT EDIT.
+
+package birdactyl
+
+import pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+
+type Server struct {
+	ID                string
+	Name              string
+	OwnerID           string
+	NodeID            string
+	Status            string
+	Memory            int32
+	CPU               int32
+	Disk              int32
+	Suspended         bool
+	PackageID         string
+	PrimaryAllocation string
+}
+
+func serverFromProto(p *pb.Server) *Server {
+	return &Server{
+		ID:                p.GetId(),
+		Name:              p.GetName(),
+		OwnerID:           p.GetUserId(),
+		NodeID:            p.GetNodeId(),
+		Status:            p.GetStatus(),
+		Memory:            p.GetMemory(),
+		CPU:               p.GetCpu(),
+		Disk:              p.GetDisk(),
+		Suspended:         p.GetSuspended(),
+		PackageID:         p.GetPackageId(),
+		PrimaryAllocation: p.GetPrimaryAllocation(),
+	}
+}
+
+func serverToProto(v *Server) *pb.Server {
+	return &pb.Server{
+		Id:                v.ID,
+		Name:              v.Name,
+		UserId:            v.OwnerID,
+		NodeId:            v.NodeID,
+		Status:            v.Status,
+		Memory:            v.Memory,
+		Cpu:               v.CPU,
+		Disk:              v.Disk,
+		Suspended:         v.Suspended,
+		PackageId:         v.PackageID,
+		PrimaryAllocation: v.PrimaryAllocation,
+	}
+}
+
+type ServerStats struct {
+	MemoryBytes int64
+	MemoryLimit int64
+	CPUPercent  float64
+	DiskBytes   int64
+	NetworkRx   int64
+	NetworkTx   int64
+	State       string
+}
+
+func serverStatsFromProto(p *pb.ServerStats) *ServerStats {
+	return &ServerStats{
+		MemoryBytes: p.GetMemoryBytes(),
+		MemoryLimit: p.GetMemoryLimit(),
+		CPUPercent:  p.GetCpuPercent(),
+		DiskBytes:   p.GetDiskBytes(),
+		NetworkRx:   p.GetNetworkRx(),
+		NetworkTx:   p.GetNetworkTx(),
+		State:       p.GetState(),
+	}
+}
+
+func serverStatsToProto(v *ServerStats) *pb.ServerStats {
+	return &pb.ServerStats{
+		MemoryBytes: v.MemoryBytes,
+		MemoryLimit: v.MemoryLimit,
+		CpuPercent:  v.CPUPercent,
+		DiskBytes:   v.DiskBytes,
+		NetworkRx:   v.NetworkRx,
+		NetworkTx:   v.NetworkTx,
+		State:       v.State,
+	}
+}
+
+type LogMatch struct {
+	Line       string
+	LineNumber int32
+	Timestamp  int64
+}
+
+func logMatchFromProto(p *pb.LogMatch) *LogMatch {
+	return &LogMatch{
+		Line:       p.GetLine(),
+		LineNumber: p.GetLineNumber(),
+		Timestamp:  p.GetTimestamp(),
+	}
+}
+
+func logMatchToProto(v *LogMatch) *pb.LogMatch {
+	return &pb.LogMatch{
+		Line:       v.Line,
+		LineNumber: v.LineNumber,
+		Timestamp:  v.Timestamp,
+	}
+}
+
+type LogFile struct {
+	Name     string
+	Size     int64
+	Modified string
+}
+
+func logFileFromProto(p *pb.LogFileInfo) *LogFile {
+	return &LogFile{
+		Name:     p.GetName(),
+		Size:     p.GetSize(),
+		Modified: p.GetModified(),
+	}
+}
+
+func logFileToProto(v *LogFile) *pb.LogFileInfo {
+	return &pb.LogFileInfo{
+		Name:     v.Name,
+		Size:     v.Size,
+		Modified: v.Modified,
+	}
+}
+
+type User struct {
+	ID                 string
+	Username           string
+	Email              string
+	IsAdmin            bool
+	IsBanned           bool
+	RamLimit           int32
+	CpuLimit           int32
+	DiskLimit          int32
+	ServerLimit        int32
+	ForcePasswordReset bool
+	CreatedAt          string
+}
+
+func userFromProto(p *pb.User) *User {
+	return &User{
+		ID:                 p.GetId(),
+		Username:           p.GetUsername(),
+		Email:              p.GetEmail(),
+		IsAdmin:            p.GetIsAdmin(),
+		IsBanned:           p.GetIsBanned(),
+		RamLimit:           p.GetRamLimit(),
+		CpuLimit:           p.GetCpuLimit(),
+		DiskLimit:          p.GetDiskLimit(),
+		ServerLimit:        p.GetServerLimit(),
+		ForcePasswordReset: p.GetForcePasswordReset(),
+		CreatedAt:          p.GetCreatedAt(),
+	}
+}
+
+func userToProto(v *User) *pb.User {
+	return &pb.User{
+		Id:                 v.ID,
+		Username:           v.Username,
+		Email:              v.Email,
+		IsAdmin:            v.IsAdmin,
+		IsBanned:           v.IsBanned,
+		RamLimit:           v.RamLimit,
+		CpuLimit:           v.CpuLimit,
+		DiskLimit:          v.DiskLimit,
+		ServerLimit:        v.ServerLimit,
+		ForcePasswordReset: v.ForcePasswordReset,
+		CreatedAt:          v.CreatedAt,
+	}
+}
+
+type Subuser struct {
+	ID          string
+	UserID      string
+	Username    string
+	Email       string
+	Permissions []string
+}
+
+func subuserFromProto(p *pb.Subuser) *Subuser {
+	return &Subuser{
+		ID:          p.GetId(),
+		UserID:      p.GetUserId(),
+		Username:    p.GetUsername(),
+		Email:       p.GetEmail(),
+		Permissions: p.GetPermissions(),
+	}
+}
+
+func subuserToProto(v *Subuser) *pb.Subuser {
+	return &pb.Subuser{
+		Id:          v.ID,
+		UserId:      v.UserID,
+		Username:    v.Username,
+		Email:       v.Email,
+		Permissions: v.Permissions,
+	}
+}
+
+type Database struct {
+	ID       string
+	Name     string
+	Username string
+	Password string
+	Host     string
+	Port     int32
+}
+
+func databaseFromProto(p *pb.Database) *Database {
+	return &Database{
+		ID:       p.GetId(),
+		Name:     p.GetName(),
+		Username: p.GetUsername(),
+		Password: p.GetPassword(),
+		Host:     p.GetHost(),
+		Port:     p.GetPort(),
+	}
+}
+
+func databaseToProto(v *Database) *pb.Database {
+	return &pb.Database{
+		Id:       v.ID,
+		Name:     v.Name,
+		Username: v.Username,
+		Password: v.Password,
+		Host:     v.Host,
+		Port:     v.Port,
+	}
+}
+
+type DatabaseHost struct {
+	ID             string
+	Name           string
+	Host           string
+	Port           int32
+	Username       string
+	MaxDatabases   int32
+	DatabasesCount int32
+}
+
+func databaseHostFromProto(p *pb.DatabaseHost) *DatabaseHost {
+	return &DatabaseHost{
+		ID:             p.GetId(),
+		Name:           p.GetName(),
+		Host:           p.GetHost(),
+		Port:           p.GetPort(),
+		Username:       p.GetUsername(),
+		MaxDatabases:   p.GetMaxDatabases(),
+		DatabasesCount: p.GetDatabasesCount(),
+	}
+}
+
+func databaseHostToProto(v *DatabaseHost) *pb.DatabaseHost {
+	return &pb.DatabaseHost{
+		Id:             v.ID,
+		Name:           v.Name,
+		Host:           v.Host,
+		Port:           v.Port,
+		Username:       v.Username,
+		MaxDatabases:   v.MaxDatabases,
+		DatabasesCount: v.DatabasesCount,
+	}
+}
+
+type File struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime string
+	Mime    string
+}
+
+func fileFromProto(p *pb.FileInfo) *File {
+	return &File{
+		Name:    p.GetName(),
+		IsDir:   p.GetIsDir(),
+		Size:    p.GetSize(),
+		ModTime: p.GetModified(),
+		Mime:    p.GetMime(),
+	}
+}
+
+func fileToProto(v *File) *pb.FileInfo {
+	return &pb.FileInfo{
+		Name:     v.Name,
+		IsDir:    v.IsDir,
+		Size:     v.Size,
+		Modified: v.ModTime,
+		Mime:     v.Mime,
+	}
+}
+
+type Backup struct {
+	ID               string
+	Name             string
+	Size             int64
+	CreatedAt        string
+	Checksum         string
+	Compression      string
+	Files            []string
+	UncompressedSize int64
+}
+
+func backupFromProto(p *pb.Backup) *Backup {
+	return &Backup{
+		ID:               p.GetId(),
+		Name:             p.GetName(),
+		Size:             p.GetSize(),
+		CreatedAt:        p.GetCreatedAt(),
+		Checksum:         p.GetChecksum(),
+		Compression:      p.GetCompression(),
+		Files:            p.GetFiles(),
+		UncompressedSize: p.GetUncompressedSize(),
+	}
+}
+
+func backupToProto(v *Backup) *pb.Backup {
+	return &pb.Backup{
+		Id:               v.ID,
+		Name:             v.Name,
+		Size:             v.Size,
+		CreatedAt:        v.CreatedAt,
+		Checksum:         v.Checksum,
+		Compression:      v.Compression,
+		Files:            v.Files,
+		UncompressedSize: v.UncompressedSize,
+	}
+}
+
+type Node struct {
+	ID              string
+	Name            string
+	FQDN            string
+	Port            int32
+	IsOnline        bool
+	LastHeartbeat   string
+	MemoryTotal     int64
+	MemoryAllocated int64
+	DiskTotal       int64
+	DiskAllocated   int64
+	CPUTotal        int64
+	CPUAllocated    int64
+	ServerCount     int32
+	Labels          map[string]string
+}
+
+func nodeFromProto(p *pb.Node) *Node {
+	return &Node{
+		ID:              p.GetId(),
+		Name:            p.GetName(),
+		FQDN:            p.GetFqdn(),
+		Port:            p.GetPort(),
+		IsOnline:        p.GetIsOnline(),
+		LastHeartbeat:   p.GetLastHeartbeat(),
+		MemoryTotal:     p.GetMemoryTotal(),
+		MemoryAllocated: p.GetMemoryAllocated(),
+		DiskTotal:       p.GetDiskTotal(),
+		DiskAllocated:   p.GetDiskAllocated(),
+		CPUTotal:        p.GetCpuTotal(),
+		CPUAllocated:    p.GetCpuAllocated(),
+		ServerCount:     p.GetServerCount(),
+		Labels:          p.GetLabels(),
+	}
+}
+
+func nodeToProto(v *Node) *pb.Node {
+	return &pb.Node{
+		Id:              v.ID,
+		Name:            v.Name,
+		Fqdn:            v.FQDN,
+		Port:            v.Port,
+		IsOnline:        v.IsOnline,
+		LastHeartbeat:   v.LastHeartbeat,
+		MemoryTotal:     v.MemoryTotal,
+		MemoryAllocated: v.MemoryAllocated,
+		DiskTotal:       v.DiskTotal,
+		DiskAllocated:   v.DiskAllocated,
+		CpuTotal:        v.CPUTotal,
+		CpuAllocated:    v.CPUAllocated,
+		ServerCount:     v.ServerCount,
+		Labels:          v.Labels,
+	}
+}
+
+type Package struct {
+	ID             string
+	Name           string
+	Description    string
+	DockerImage    string
+	StartupCommand string
+	StopCommand    string
+	ConfigFiles    string
+	Memory         int32
+	CPU            int32
+	Disk           int32
+	IsPublic       bool
+}
+
+func packageFromProto(p *pb.Package) *Package {
+	return &Package{
+		ID:             p.GetId(),
+		Name:           p.GetName(),
+		Description:    p.GetDescription(),
+		DockerImage:    p.GetDockerImage(),
+		StartupCommand: p.GetStartupCommand(),
+		StopCommand:    p.GetStopCommand(),
+		ConfigFiles:    p.GetConfigFiles(),
+		Memory:         p.GetDefaultMemory(),
+		CPU:            p.GetDefaultCpu(),
+		Disk:           p.GetDefaultDisk(),
+		IsPublic:       p.GetIsPublic(),
+	}
+}
+
+func packageToProto(v *Package) *pb.Package {
+	return &pb.Package{
+		Id:             v.ID,
+		Name:           v.Name,
+		Description:    v.Description,
+		DockerImage:    v.DockerImage,
+		StartupCommand: v.StartupCommand,
+		StopCommand:    v.StopCommand,
+		ConfigFiles:    v.ConfigFiles,
+		DefaultMemory:  v.Memory,
+		DefaultCpu:     v.CPU,
+		DefaultDisk:    v.Disk,
+		IsPublic:       v.IsPublic,
+	}
+}
+
+type IPBan struct {
+	ID        string
+	IP        string
+	Reason    string
+	CreatedAt string
+}
+
+func ipBanFromProto(p *pb.IPBan) *IPBan {
+	return &IPBan{
+		ID:        p.GetId(),
+		IP:        p.GetIp(),
+		Reason:    p.GetReason(),
+		CreatedAt: p.GetCreatedAt(),
+	}
+}
+
+func ipBanToProto(v *IPBan) *pb.IPBan {
+	return &pb.IPBan{
+		Id:        v.ID,
+		Ip:        v.IP,
+		Reason:    v.Reason,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+type Settings struct {
+	RegistrationEnabled   bool
+	ServerCreationEnabled bool
+}
+
+func settingsFromProto(p *pb.Settings) *Settings {
+	return &Settings{
+		RegistrationEnabled:   p.GetRegistrationEnabled(),
+		ServerCreationEnabled: p.GetServerCreationEnabled(),
+	}
+}
+
+func settingsToProto(v *Settings) *pb.Settings {
+	return &pb.Settings{
+		RegistrationEnabled:   v.RegistrationEnabled,
+		ServerCreationEnabled: v.ServerCreationEnabled,
+	}
+}
+
+type ActivityLog struct {
+	ID          string
+	UserID      string
+	Username    string
+	Action      string
+	Description string
+	IP          string
+	IsAdmin     bool
+	CreatedAt   string
+}
+
+func activityLogFromProto(p *pb.ActivityLog) *ActivityLog {
+	return &ActivityLog{
+		ID:          p.GetId(),
+		UserID:      p.GetUserId(),
+		Username:    p.GetUsername(),
+		Action:      p.GetAction(),
+		Description: p.GetDescription(),
+		IP:          p.GetIp(),
+		IsAdmin:     p.GetIsAdmin(),
+		CreatedAt:   p.GetCreatedAt(),
+	}
+}
+
+func activityLogToProto(v *ActivityLog) *pb.ActivityLog {
+	return &pb.ActivityLog{
+		Id:          v.ID,
+		UserId:      v.UserID,
+		Username:    v.Username,
+		Action:      v.Action,
+		Description: v.Description,
+		Ip:          v.IP,
+		IsAdmin:     v.IsAdmin,
+		CreatedAt:   v.CreatedAt,
+	}
+}
+
+type UploadStatus struct {
+	UploadID string
+	Path     string
+	Received int64
+	Complete bool
+}
+
+func uploadStatusFromProto(p *pb.UploadStatus) *UploadStatus {
+	return &UploadStatus{
+		UploadID: p.GetUploadId(),
+		Path:     p.GetPath(),
+		Received: p.GetReceived(),
+		Complete: p.GetComplete(),
+	}
+}
+
+func uploadStatusToProto(v *UploadStatus) *pb.UploadStatus {
+	return &pb.UploadStatus{
+		UploadId: v.UploadID,
+		Path:     v.Path,
+		Received: v.Received,
+		Complete: v.Complete,
+	}
+}
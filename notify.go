@@ -0,0 +1,257 @@
+package birdactyl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+const (
+	NotifyInfo    = "info"
+	NotifySuccess = "success"
+	NotifyWarning = "warning"
+	NotifyError   = "error"
+)
+
+var ErrNotificationRateLimited = errors.New("birdactyl: notification rate limit exceeded")
+
+type NotificationLimit struct {
+	Burst int
+	Per   time.Duration
+}
+
+var DefaultNotificationLimit = NotificationLimit{Burst: 5, Per: time.Minute}
+
+type NotificationCenter struct {
+	mu        sync.Mutex
+	limit     NotificationLimit
+	buckets   map[string]*notifyBucket
+	swept     time.Time
+	templates map[string]*notifyTemplate
+}
+
+type notifyBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type notifyTemplate struct {
+	title   *template.Template
+	message *template.Template
+}
+
+func NewNotificationCenter(limit NotificationLimit) *NotificationCenter {
+	return &NotificationCenter{limit: limit, buckets: make(map[string]*notifyBucket), templates: make(map[string]*notifyTemplate)}
+}
+
+func (p *Plugin) UseNotificationLimit(limit NotificationLimit) *Plugin {
+	p.notifications.SetLimit(limit)
+	return p
+}
+
+func (p *Plugin) NotificationTemplate(name, title, message string) *Plugin {
+	if err := p.notifications.Template(name, title, message); err != nil {
+		return p.setupError(fmt.Errorf("notification template %q: %w", name, err))
+	}
+	return p
+}
+
+func (p *Plugin) Notifications() *NotificationCenter {
+	return p.notifications
+}
+
+func (n *NotificationCenter) SetLimit(limit NotificationLimit) {
+	n.mu.Lock()
+	n.limit = limit
+	n.buckets = make(map[string]*notifyBucket)
+	n.mu.Unlock()
+}
+
+func (n *NotificationCenter) Template(name, title, message string) error {
+	t, err := template.New(name + ".title").Parse(title)
+	if err != nil {
+		return err
+	}
+	m, err := template.New(name + ".message").Parse(message)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.templates[name] = &notifyTemplate{title: t, message: m}
+	n.mu.Unlock()
+	return nil
+}
+
+func (n *NotificationCenter) Render(name string, data interface{}) (string, string, error) {
+	n.mu.Lock()
+	t, ok := n.templates[name]
+	n.mu.Unlock()
+	if !ok {
+		return "", "", fmt.Errorf("notification template %q not registered", name)
+	}
+	var title, message bytes.Buffer
+	if err := t.title.Execute(&title, data); err != nil {
+		return "", "", err
+	}
+	if err := t.message.Execute(&message, data); err != nil {
+		return "", "", err
+	}
+	return title.String(), message.String(), nil
+}
+
+func (n *NotificationCenter) Allow(userID string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.limit.Burst <= 0 || n.limit.Per <= 0 {
+		return true
+	}
+	now := time.Now()
+	if now.Sub(n.swept) >= n.limit.Per {
+		for id, b := range n.buckets {
+			if now.Sub(b.last) >= n.limit.Per {
+				delete(n.buckets, id)
+			}
+		}
+		n.swept = now
+	}
+	b, ok := n.buckets[userID]
+	if !ok {
+		b = &notifyBucket{tokens: float64(n.limit.Burst), last: now}
+		n.buckets[userID] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * float64(n.limit.Burst) / n.limit.Per.Seconds()
+	if b.tokens > float64(n.limit.Burst) {
+		b.tokens = float64(n.limit.Burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (a *API) Notify(userID, title, message, notifType string) error {
+	if a.notifications != nil && !a.notifications.Allow(userID) {
+		return ErrNotificationRateLimited
+	}
+	req := &pb.NotificationRequest{UserId: userID, Title: title, Message: message, Type: notifType}
+	_, err := a.panel.SendNotification(a.ctx(), req)
+	return err
+}
+
+func (a *API) SendNotification(userID, title, message, notifType string) error {
+	return a.Notify(userID, title, message, notifType)
+}
+
+func (a *API) NotifyTemplate(userID, name string, data interface{}, notifType string) error {
+	if a.notifications == nil {
+		return fmt.Errorf("notification template %q not registered", name)
+	}
+	title, message, err := a.notifications.Render(name, data)
+	if err != nil {
+		return err
+	}
+	return a.Notify(userID, title, message, notifType)
+}
+
+func (a *API) NotifyUsers(userIDs []string, title, message, notifType string) error {
+	seen := make(map[string]bool, len(userIDs))
+	var errs []error
+	for _, id := range userIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if err := a.Notify(id, title, message, notifType); err != nil {
+			errs = append(errs, fmt.Errorf("notify %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *API) NotifyAdmins(title, message, notifType string) error {
+	users, err := a.listUsers()
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+	var ids []string
+	for _, u := range users {
+		if u.IsAdmin {
+			ids = append(ids, u.ID)
+		}
+	}
+	return a.NotifyUsers(ids, title, message, notifType)
+}
+
+func (a *API) NotifyNodeOwners(nodeID, title, message, notifType string) error {
+	servers, err := a.listServers()
+	if err != nil {
+		return fmt.Errorf("list servers: %w", err)
+	}
+	var ids []string
+	for _, s := range servers {
+		if s.NodeID == nodeID {
+			ids = append(ids, s.OwnerID)
+		}
+	}
+	return a.NotifyUsers(ids, title, message, notifType)
+}
+
+func (a *API) NotifySubusers(serverID, title, message, notifType string) error {
+	subusers, err := a.listSubusers(serverID)
+	if err != nil {
+		return fmt.Errorf("list subusers: %w", err)
+	}
+	var ids []string
+	for _, s := range subusers {
+		ids = append(ids, s.UserID)
+	}
+	return a.NotifyUsers(ids, title, message, notifType)
+}
+
+func (a *AsyncAPI) Notify(userID, title, message, notifType string) *Future[struct{}] {
+	return submit(a, "Notify", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).Notify(userID, title, message, notifType)
+	})
+}
+
+func (a *AsyncAPI) NotifyTemplate(userID, name string, data interface{}, notifType string) *Future[struct{}] {
+	return submit(a, "NotifyTemplate", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).NotifyTemplate(userID, name, data, notifType)
+	})
+}
+
+func (a *AsyncAPI) NotifyUsers(userIDs []string, title, message, notifType string) *Future[struct{}] {
+	return submit(a, "NotifyUsers", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).NotifyUsers(userIDs, title, message, notifType)
+	})
+}
+
+func (a *AsyncAPI) SendNotification(userID, title, message, notifType string) *Future[struct{}] {
+	return a.Notify(userID, title, message, notifType)
+}
+
+func (a *AsyncAPI) NotifyAdmins(title, message, notifType string) *Future[struct{}] {
+	return submit(a, "NotifyAdmins", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).NotifyAdmins(title, message, notifType)
+	})
+}
+
+func (a *AsyncAPI) NotifyNodeOwners(nodeID, title, message, notifType string) *Future[struct{}] {
+	return submit(a, "NotifyNodeOwners", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).NotifyNodeOwners(nodeID, title, message, notifType)
+	})
+}
+
+func (a *AsyncAPI) NotifySubusers(serverID, title, message, notifType string) *Future[struct{}] {
+	return submit(a, "NotifySubusers", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.api(ctx).NotifySubusers(serverID, title, message, notifType)
+	})
+}
@@ -0,0 +1,296 @@
+package birdactyl
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrNoNodeAvailable = errors.New("birdactyl: no online node has capacity for the server")
+
+var PlacementReservationTTL = 2 * time.Minute
+
+type PlacementRequest struct {
+	Resources ServerResources
+	OwnerID   string
+	Labels    map[string]string
+}
+
+type PlacementCandidate struct {
+	Node     *Node
+	Servers  []*Server
+	Reserved ServerResources
+}
+
+func (c PlacementCandidate) dims(req ServerResources) [][3]int64 {
+	return [][3]int64{
+		{c.Node.MemoryTotal, c.Node.MemoryAllocated + int64(c.Reserved.Memory), int64(req.Memory)},
+		{c.Node.DiskTotal, c.Node.DiskAllocated + int64(c.Reserved.Disk), int64(req.Disk)},
+		{c.Node.CPUTotal, c.Node.CPUAllocated + int64(c.Reserved.CPU), int64(req.CPU)},
+	}
+}
+
+func (c PlacementCandidate) Fits(req ServerResources) bool {
+	for _, d := range c.dims(req) {
+		if d[0] > 0 && d[1]+d[2] > d[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c PlacementCandidate) Utilization(req ServerResources) float64 {
+	peak := 0.0
+	for _, d := range c.dims(req) {
+		if d[0] > 0 {
+			peak = max(peak, float64(d[1]+d[2])/float64(d[0]))
+		}
+	}
+	return peak
+}
+
+type PlacementStrategy interface {
+	Score(c PlacementCandidate, req PlacementRequest) (float64, bool)
+}
+
+type PlacementStrategyFunc func(c PlacementCandidate, req PlacementRequest) (float64, bool)
+
+func (f PlacementStrategyFunc) Score(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+	return f(c, req)
+}
+
+func LeastLoaded() PlacementStrategy {
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		return 1 - c.Utilization(req.Resources), true
+	})
+}
+
+func BinPack() PlacementStrategy {
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		return c.Utilization(req.Resources), true
+	})
+}
+
+func SpreadByOwner() PlacementStrategy {
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		if req.OwnerID == "" {
+			return 1, true
+		}
+		owned := 0
+		for _, s := range c.Servers {
+			if s.OwnerID == req.OwnerID {
+				owned++
+			}
+		}
+		return 1 / float64(1+owned), true
+	})
+}
+
+func RequireLabels(selector map[string]string) PlacementStrategy {
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		return 1, labelsMatch(c.Node.Labels, selector)
+	})
+}
+
+func PreferLabels(labels map[string]string) PlacementStrategy {
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		if len(labels) == 0 {
+			return 1, true
+		}
+		matched := 0
+		for k, v := range labels {
+			if got, ok := c.Node.Labels[k]; ok && got == v {
+				matched++
+			}
+		}
+		return float64(matched) / float64(len(labels)), true
+	})
+}
+
+func Affinity(serverIDs ...string) PlacementStrategy {
+	ids := toSet(serverIDs)
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		for _, s := range c.Servers {
+			if ids[s.ID] {
+				return 1, true
+			}
+		}
+		return 0, true
+	})
+}
+
+func AntiAffinity(serverIDs ...string) PlacementStrategy {
+	ids := toSet(serverIDs)
+	return PlacementStrategyFunc(func(c PlacementCandidate, req PlacementRequest) (float64, bool) {
+		for _, s := range c.Servers {
+			if ids[s.ID] {
+				return 0, false
+			}
+		}
+		return 1, true
+	})
+}
+
+func labelsMatch(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		got, ok := labels[k]
+		if !ok || (v != "" && got != v) {
+			return false
+		}
+	}
+	return true
+}
+
+type PlacementScore struct {
+	Node  *Node
+	Score float64
+}
+
+type weightedStrategy struct {
+	strategy PlacementStrategy
+	weight   float64
+}
+
+type pendingPlacement struct {
+	nodeID    string
+	resources ServerResources
+	expires   time.Time
+}
+
+type Placer struct {
+	mu         sync.Mutex
+	api        *API
+	strategies []weightedStrategy
+	reserved   map[string]ServerResources
+	pending    map[string]pendingPlacement
+}
+
+func (a *API) Placer() *Placer {
+	if a.placer == nil {
+		a.placer = NewPlacer(a)
+	}
+	return a.placer
+}
+
+func NewPlacer(api *API, strategies ...PlacementStrategy) *Placer {
+	p := &Placer{api: api, reserved: make(map[string]ServerResources), pending: make(map[string]pendingPlacement)}
+	for _, s := range strategies {
+		p.Weighted(s, 1)
+	}
+	return p
+}
+
+func (p *Placer) Weighted(s PlacementStrategy, weight float64) *Placer {
+	p.mu.Lock()
+	p.strategies = append(p.strategies, weightedStrategy{s, weight})
+	p.mu.Unlock()
+	return p
+}
+
+func (p *Placer) Rank(req PlacementRequest) ([]PlacementScore, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rank(req)
+}
+
+func (p *Placer) rank(req PlacementRequest) ([]PlacementScore, error) {
+	strategies := p.strategies
+	if len(strategies) == 0 {
+		strategies = []weightedStrategy{{LeastLoaded(), 1}}
+	}
+
+	servers, err := p.api.listServers()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := p.api.listNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	byNode := make(map[string][]*Server)
+	listed := make(map[string]bool, len(servers))
+	for _, s := range servers {
+		byNode[s.NodeID] = append(byNode[s.NodeID], s)
+		listed[s.ID] = true
+	}
+	reserved := make(map[string]ServerResources, len(p.reserved))
+	for id, r := range p.reserved {
+		reserved[id] = r
+	}
+	now := time.Now()
+	for id, pp := range p.pending {
+		if listed[id] || now.After(pp.expires) {
+			delete(p.pending, id)
+			continue
+		}
+		reserved[pp.nodeID] = reserved[pp.nodeID].add(pp.resources)
+	}
+
+	var out []PlacementScore
+nodes:
+	for _, n := range nodes {
+		c := PlacementCandidate{Node: n, Servers: byNode[n.ID], Reserved: reserved[n.ID]}
+		if !n.IsOnline || !c.Fits(req.Resources) || !labelsMatch(n.Labels, req.Labels) {
+			continue
+		}
+		total := 0.0
+		for _, ws := range strategies {
+			score, ok := ws.strategy.Score(c, req)
+			if !ok {
+				continue nodes
+			}
+			total += ws.weight * score
+		}
+		out = append(out, PlacementScore{Node: n, Score: total})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Node.ID < out[j].Node.ID
+	})
+	return out, nil
+}
+
+func (p *Placer) Place(req PlacementRequest) (*Node, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ranked, err := p.rank(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranked) == 0 {
+		return nil, ErrNoNodeAvailable
+	}
+	node := ranked[0].Node
+	p.reserved[node.ID] = p.reserved[node.ID].add(req.Resources)
+	return node, nil
+}
+
+func (p *Placer) Release(nodeID string, res ServerResources) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.release(nodeID, res)
+}
+
+func (p *Placer) Commit(nodeID, serverID string, res ServerResources) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.release(nodeID, res)
+	p.pending[serverID] = pendingPlacement{nodeID: nodeID, resources: res, expires: time.Now().Add(PlacementReservationTTL)}
+}
+
+func (p *Placer) release(nodeID string, res ServerResources) {
+	r := p.reserved[nodeID].add(ServerResources{Memory: -res.Memory, CPU: -res.CPU, Disk: -res.Disk})
+	if r == (ServerResources{}) {
+		delete(p.reserved, nodeID)
+	} else {
+		p.reserved[nodeID] = r
+	}
+}
+
+func (r ServerResources) add(o ServerResources) ServerResources {
+	return ServerResources{Memory: r.Memory + o.Memory, CPU: r.CPU + o.CPU, Disk: r.Disk + o.Disk}
+}
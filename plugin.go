@@ -3,12 +3,14 @@ package birdactyl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
 	"google.golang.org/grpc"
@@ -17,21 +19,37 @@ import (
 )
 
 type Plugin struct {
-	id         string
-	name       string
-	version    string
-	events     map[string]EventHandler
-	routes     map[string]RouteHandler
-	schedule   map[string]ScheduleHandler
-	mixins     []MixinRegistration
-	panel      pb.PanelServiceClient
-	conn       *grpc.ClientConn
-	api        *API
-	asyncApi   *AsyncAPI
-	dataDir    string
-	useDataDir bool
-	onStart    func()
-	readyCh    chan struct{}
+	id            string
+	name          string
+	version       string
+	events        map[string]EventHandler
+	routes        map[string]RouteHandler
+	schedule      map[string]ScheduleHandler
+	mixins        []MixinRegistration
+	panel         pb.PanelServiceClient
+	conn          *grpc.ClientConn
+	api           *API
+	asyncApi      *AsyncAPI
+	executor      *Executor
+	console       *Console
+	triggers      *consoleTriggers
+	bus           *eventBus
+	statsOpts     *StatsOptions
+	stats         *StatsCollector
+	alerts        *AlertEngine
+	notifications *NotificationCenter
+	webhooks      *WebhookDispatcher
+	cache         *CachedAPI
+	backups       *BackupManager
+	migrations    []Migration
+	store         *Store
+	storeErr      error
+	storeOnce     sync.Once
+	dataDir       string
+	useDataDir    bool
+	onStart       func()
+	readyCh       chan struct{}
+	setupErrs     []error
 }
 
 type EventHandler func(Event) EventResult
@@ -39,16 +57,30 @@ type RouteHandler func(Request) Response
 type ScheduleHandler func()
 
 func New(id, version string) *Plugin {
-	return &Plugin{
-		id:       id,
-		name:     id,
-		version:  version,
-		events:   make(map[string]EventHandler),
-		routes:   make(map[string]RouteHandler),
-		schedule: make(map[string]ScheduleHandler),
-		mixins:   make([]MixinRegistration, 0),
-		readyCh:  make(chan struct{}),
+	p := &Plugin{
+		id:            id,
+		name:          id,
+		version:       version,
+		events:        make(map[string]EventHandler),
+		routes:        make(map[string]RouteHandler),
+		schedule:      make(map[string]ScheduleHandler),
+		mixins:        make([]MixinRegistration, 0),
+		console:       newConsole(DefaultConsoleOptions),
+		triggers:      newConsoleTriggers(),
+		bus:           newEventBus(),
+		alerts:        NewAlertEngine(),
+		notifications: NewNotificationCenter(DefaultNotificationLimit),
+		backups:       newBackupManager(),
+		readyCh:       make(chan struct{}),
 	}
+	p.webhooks = newWebhookDispatcher(p.bus)
+	return p
+}
+
+func (p *Plugin) setupError(err error) *Plugin {
+	log.Printf("[%s] %v", p.id, err)
+	p.setupErrs = append(p.setupErrs, err)
+	return p
 }
 
 func (p *Plugin) SetName(name string) *Plugin {
@@ -61,6 +93,16 @@ func (p *Plugin) UseDataDir() *Plugin {
 	return p
 }
 
+func (p *Plugin) UseExecutor(e *Executor) *Plugin {
+	p.executor = e
+	return p
+}
+
+func (p *Plugin) UseConsoleOptions(opts ConsoleOptions) *Plugin {
+	p.console.SetOptions(opts)
+	return p
+}
+
 func (p *Plugin) OnStart(fn func()) *Plugin {
 	p.onStart = fn
 	return p
@@ -102,6 +144,10 @@ func (p *Plugin) Async() *AsyncAPI {
 	return p.asyncApi
 }
 
+func (p *Plugin) Console() *Console {
+	return p.console
+}
+
 func (p *Plugin) Log(msg string) {
 	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-plugin-id", p.id)
 	p.panel.Log(ctx, &pb.LogRequest{Level: "info", Message: msg})
@@ -132,6 +178,10 @@ func (p *Plugin) LoadConfig(v interface{}) error {
 }
 
 func (p *Plugin) Start(panelAddr string, defaultPort int) error {
+	if err := errors.Join(p.setupErrs...); err != nil {
+		return err
+	}
+
 	port := defaultPort
 	if len(os.Args) > 1 {
 		if pt, err := strconv.Atoi(os.Args[1]); err == nil {
@@ -163,8 +213,25 @@ func (p *Plugin) Start(panelAddr string, defaultPort int) error {
 	}
 	p.conn = conn
 	p.panel = pb.NewPanelServiceClient(conn)
-	p.api = &API{panel: p.panel, pluginID: p.id}
-	p.asyncApi = &AsyncAPI{panel: p.panel, pluginID: p.id}
+	p.api = &API{panel: p.panel, pluginID: p.id, console: p.console, events: p.bus, notifications: p.notifications}
+	if p.executor == nil {
+		p.executor = NewExecutor(DefaultExecutorConfig)
+	}
+	p.api.placer = NewPlacer(p.api)
+	p.asyncApi = &AsyncAPI{panel: p.panel, pluginID: p.id, exec: p.executor, notifications: p.notifications, placer: p.api.placer}
+	p.console.attach(p.api)
+	if p.cache != nil {
+		p.cache.attach(p.api)
+	}
+	p.backups.api = p.api
+	if p.statsOpts != nil {
+		opts := *p.statsOpts
+		if opts.PersistDir == "" {
+			opts.PersistDir = p.DataPath("stats")
+		}
+		p.stats = NewStatsCollector(p.asyncApi, opts)
+		p.alerts.Attach(p.api, p.asyncApi, p.stats)
+	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
@@ -176,15 +243,39 @@ func (p *Plugin) Start(panelAddr string, defaultPort int) error {
 
 	log.Printf("[%s] v%s listening on port %d", p.id, p.version, port)
 
+	failed := make(chan error, 1)
 	go func() {
 		<-p.readyCh
+		if len(p.migrations) > 0 {
+			applied, err := NewMigrator(p.api, p.migrations).Up()
+			if err != nil {
+				log.Printf("[%s] migrations failed: %v", p.id, err)
+				failed <- fmt.Errorf("migrations: %w", err)
+				s.Stop()
+				return
+			}
+			if len(applied) > 0 {
+				log.Printf("[%s] applied migrations %v", p.id, applied)
+			}
+		}
+		p.triggers.start(p.api, p.asyncApi, p.console)
+		p.webhooks.start(p.api, p.DataPath("webhooks"))
+		if p.stats != nil {
+			p.stats.Start()
+		}
 		if p.onStart != nil {
 			p.onStart()
 		}
 		p.Log(p.name + " v" + p.version + " started")
 	}()
 
-	return s.Serve(lis)
+	err = s.Serve(lis)
+	select {
+	case ferr := <-failed:
+		return ferr
+	default:
+		return err
+	}
 }
 
 type pluginServer struct {
@@ -197,6 +288,11 @@ func (s *pluginServer) GetInfo(ctx context.Context, req *pb.Empty) (*pb.PluginIn
 	for e := range s.plugin.events {
 		events = append(events, e)
 	}
+	for _, e := range s.plugin.bus.types() {
+		if _, ok := s.plugin.events[e]; !ok {
+			events = append(events, e)
+		}
+	}
 
 	routes := make([]*pb.RouteInfo, 0, len(s.plugin.routes))
 	for key := range s.plugin.routes {
@@ -235,11 +331,16 @@ func (s *pluginServer) GetInfo(ctx context.Context, req *pb.Empty) (*pb.PluginIn
 }
 
 func (s *pluginServer) OnEvent(ctx context.Context, ev *pb.Event) (*pb.EventResponse, error) {
+	event := Event{Type: ev.Type, Data: ev.Data, Sync: ev.Sync}
 	handler, ok := s.plugin.events[ev.Type]
 	if !ok {
+		s.plugin.bus.publish(event)
 		return &pb.EventResponse{Allow: true}, nil
 	}
-	result := handler(Event{Type: ev.Type, Data: ev.Data, Sync: ev.Sync})
+	result := handler(event)
+	if result.allow {
+		s.plugin.bus.publish(event)
+	}
 	return &pb.EventResponse{Allow: result.allow, Message: result.message}, nil
 }
 
@@ -345,6 +446,17 @@ func (s *pluginServer) OnMixin(ctx context.Context, req *pb.MixinRequest) (*pb.M
 
 func (s *pluginServer) Shutdown(ctx context.Context, req *pb.Empty) (*pb.Empty, error) {
 	log.Printf("[%s] shutdown", s.plugin.id)
+	s.plugin.triggers.stop()
+	if s.plugin.stats != nil {
+		s.plugin.stats.Stop()
+	}
+	s.plugin.alerts.Stop()
+	s.plugin.backups.stop()
+	s.plugin.webhooks.stop()
+	s.plugin.storeOnce.Do(func() { s.plugin.storeErr = ErrStoreClosed })
+	if s.plugin.store != nil {
+		s.plugin.store.Close()
+	}
 	return &pb.Empty{}, nil
 }
 
@@ -4197,20 +4197,372 @@ func (x *MoveFileRequest) GetTo() string {
 	return ""
 }
 
-// Backups
-type Backup struct {
+type DownloadFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	ChunkSize     int32                  `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileRequest) Reset() {
+	*x = DownloadFileRequest{}
+	mi := &file_plugin_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileRequest) ProtoMessage() {}
+
+func (x *DownloadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DownloadFileRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DownloadFileRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DownloadFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadFileRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+type FileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	UploadId      string                 `protobuf:"bytes,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Eof           bool                   `protobuf:"varint,6,opt,name=eof,proto3" json:"eof,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,7,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,8,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	mi := &file_plugin_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *FileChunk) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *FileChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChunk) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *FileChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+func (x *FileChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *FileChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type UploadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
+	mi := &file_plugin_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResponse) ProtoMessage() {}
+
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UploadFileResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadFileResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadFileResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type UploadStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	UploadId      string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *UploadStatusRequest) Reset() {
+	*x = UploadStatusRequest{}
+	mi := &file_plugin_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStatusRequest) ProtoMessage() {}
+
+func (x *UploadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*UploadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UploadStatusRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *UploadStatusRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type UploadStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Received      int64                  `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	Complete      bool                   `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadStatus) Reset() {
+	*x = UploadStatus{}
+	mi := &file_plugin_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStatus) ProtoMessage() {}
+
+func (x *UploadStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStatus.ProtoReflect.Descriptor instead.
+func (*UploadStatus) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UploadStatus) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadStatus) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UploadStatus) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *UploadStatus) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+// Backups
+type Backup struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Size             int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Checksum         string                 `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Compression      string                 `protobuf:"bytes,6,opt,name=compression,proto3" json:"compression,omitempty"`
+	Files            []string               `protobuf:"bytes,7,rep,name=files,proto3" json:"files,omitempty"`
+	UncompressedSize int64                  `protobuf:"varint,8,opt,name=uncompressed_size,json=uncompressedSize,proto3" json:"uncompressed_size,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *Backup) Reset() {
 	*x = Backup{}
-	mi := &file_plugin_proto_msgTypes[66]
+	mi := &file_plugin_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4222,7 +4574,7 @@ func (x *Backup) String() string {
 func (*Backup) ProtoMessage() {}
 
 func (x *Backup) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[66]
+	mi := &file_plugin_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4235,7 +4587,7 @@ func (x *Backup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Backup.ProtoReflect.Descriptor instead.
 func (*Backup) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{66}
+	return file_plugin_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *Backup) GetId() string {
@@ -4266,6 +4618,34 @@ func (x *Backup) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Backup) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *Backup) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *Backup) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Backup) GetUncompressedSize() int64 {
+	if x != nil {
+		return x.UncompressedSize
+	}
+	return 0
+}
+
 type ListBackupsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Backups       []*Backup              `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
@@ -4275,7 +4655,7 @@ type ListBackupsResponse struct {
 
 func (x *ListBackupsResponse) Reset() {
 	*x = ListBackupsResponse{}
-	mi := &file_plugin_proto_msgTypes[67]
+	mi := &file_plugin_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4287,7 +4667,7 @@ func (x *ListBackupsResponse) String() string {
 func (*ListBackupsResponse) ProtoMessage() {}
 
 func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[67]
+	mi := &file_plugin_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4300,7 +4680,7 @@ func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{67}
+	return file_plugin_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *ListBackupsResponse) GetBackups() []*Backup {
@@ -4320,7 +4700,7 @@ type CreateBackupRequest struct {
 
 func (x *CreateBackupRequest) Reset() {
 	*x = CreateBackupRequest{}
-	mi := &file_plugin_proto_msgTypes[68]
+	mi := &file_plugin_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4332,7 +4712,7 @@ func (x *CreateBackupRequest) String() string {
 func (*CreateBackupRequest) ProtoMessage() {}
 
 func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[68]
+	mi := &file_plugin_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4345,7 +4725,7 @@ func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
 func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{68}
+	return file_plugin_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *CreateBackupRequest) GetServerId() string {
@@ -4372,7 +4752,7 @@ type DeleteBackupRequest struct {
 
 func (x *DeleteBackupRequest) Reset() {
 	*x = DeleteBackupRequest{}
-	mi := &file_plugin_proto_msgTypes[69]
+	mi := &file_plugin_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4384,7 +4764,7 @@ func (x *DeleteBackupRequest) String() string {
 func (*DeleteBackupRequest) ProtoMessage() {}
 
 func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[69]
+	mi := &file_plugin_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4397,7 +4777,7 @@ func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{69}
+	return file_plugin_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *DeleteBackupRequest) GetServerId() string {
@@ -4414,22 +4794,210 @@ func (x *DeleteBackupRequest) GetBackupId() string {
 	return ""
 }
 
-// Nodes
-type Node struct {
+type BackupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Fqdn          string                 `protobuf:"bytes,3,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
-	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	IsOnline      bool                   `protobuf:"varint,5,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
-	LastHeartbeat string                 `protobuf:"bytes,6,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	BackupId      string                 `protobuf:"bytes,2,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	mi := &file_plugin_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BackupRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *BackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+type RestoreBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	BackupId      string                 `protobuf:"bytes,2,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Truncate      bool                   `protobuf:"varint,3,opt,name=truncate,proto3" json:"truncate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreBackupRequest) Reset() {
+	*x = RestoreBackupRequest{}
+	mi := &file_plugin_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBackupRequest) ProtoMessage() {}
+
+func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreBackupRequest.ProtoReflect.Descriptor instead.
+func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RestoreBackupRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetTruncate() bool {
+	if x != nil {
+		return x.Truncate
+	}
+	return false
+}
+
+type DownloadBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	BackupId      string                 `protobuf:"bytes,2,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	ChunkSize     int32                  `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *DownloadBackupRequest) Reset() {
+	*x = DownloadBackupRequest{}
+	mi := &file_plugin_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBackupRequest) ProtoMessage() {}
+
+func (x *DownloadBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBackupRequest.ProtoReflect.Descriptor instead.
+func (*DownloadBackupRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *DownloadBackupRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DownloadBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *DownloadBackupRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadBackupRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+// Nodes
+type Node struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Fqdn            string                 `protobuf:"bytes,3,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	Port            int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	IsOnline        bool                   `protobuf:"varint,5,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	LastHeartbeat   string                 `protobuf:"bytes,6,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	MemoryTotal     int64                  `protobuf:"varint,7,opt,name=memory_total,json=memoryTotal,proto3" json:"memory_total,omitempty"`
+	MemoryAllocated int64                  `protobuf:"varint,8,opt,name=memory_allocated,json=memoryAllocated,proto3" json:"memory_allocated,omitempty"`
+	DiskTotal       int64                  `protobuf:"varint,9,opt,name=disk_total,json=diskTotal,proto3" json:"disk_total,omitempty"`
+	DiskAllocated   int64                  `protobuf:"varint,10,opt,name=disk_allocated,json=diskAllocated,proto3" json:"disk_allocated,omitempty"`
+	CpuTotal        int64                  `protobuf:"varint,11,opt,name=cpu_total,json=cpuTotal,proto3" json:"cpu_total,omitempty"`
+	CpuAllocated    int64                  `protobuf:"varint,12,opt,name=cpu_allocated,json=cpuAllocated,proto3" json:"cpu_allocated,omitempty"`
+	ServerCount     int32                  `protobuf:"varint,13,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,14,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *Node) Reset() {
 	*x = Node{}
-	mi := &file_plugin_proto_msgTypes[70]
+	mi := &file_plugin_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4441,7 +5009,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[70]
+	mi := &file_plugin_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4454,7 +5022,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{70}
+	return file_plugin_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *Node) GetId() string {
@@ -4499,6 +5067,62 @@ func (x *Node) GetLastHeartbeat() string {
 	return ""
 }
 
+func (x *Node) GetMemoryTotal() int64 {
+	if x != nil {
+		return x.MemoryTotal
+	}
+	return 0
+}
+
+func (x *Node) GetMemoryAllocated() int64 {
+	if x != nil {
+		return x.MemoryAllocated
+	}
+	return 0
+}
+
+func (x *Node) GetDiskTotal() int64 {
+	if x != nil {
+		return x.DiskTotal
+	}
+	return 0
+}
+
+func (x *Node) GetDiskAllocated() int64 {
+	if x != nil {
+		return x.DiskAllocated
+	}
+	return 0
+}
+
+func (x *Node) GetCpuTotal() int64 {
+	if x != nil {
+		return x.CpuTotal
+	}
+	return 0
+}
+
+func (x *Node) GetCpuAllocated() int64 {
+	if x != nil {
+		return x.CpuAllocated
+	}
+	return 0
+}
+
+func (x *Node) GetServerCount() int32 {
+	if x != nil {
+		return x.ServerCount
+	}
+	return 0
+}
+
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type ListNodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
@@ -4508,7 +5132,7 @@ type ListNodesResponse struct {
 
 func (x *ListNodesResponse) Reset() {
 	*x = ListNodesResponse{}
-	mi := &file_plugin_proto_msgTypes[71]
+	mi := &file_plugin_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4520,7 +5144,7 @@ func (x *ListNodesResponse) String() string {
 func (*ListNodesResponse) ProtoMessage() {}
 
 func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[71]
+	mi := &file_plugin_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4533,7 +5157,7 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListNodesResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{71}
+	return file_plugin_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *ListNodesResponse) GetNodes() []*Node {
@@ -4554,7 +5178,7 @@ type CreateNodeRequest struct {
 
 func (x *CreateNodeRequest) Reset() {
 	*x = CreateNodeRequest{}
-	mi := &file_plugin_proto_msgTypes[72]
+	mi := &file_plugin_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4566,7 +5190,7 @@ func (x *CreateNodeRequest) String() string {
 func (*CreateNodeRequest) ProtoMessage() {}
 
 func (x *CreateNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[72]
+	mi := &file_plugin_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4579,7 +5203,7 @@ func (x *CreateNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNodeRequest.ProtoReflect.Descriptor instead.
 func (*CreateNodeRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{72}
+	return file_plugin_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *CreateNodeRequest) GetName() string {
@@ -4613,7 +5237,7 @@ type NodeWithToken struct {
 
 func (x *NodeWithToken) Reset() {
 	*x = NodeWithToken{}
-	mi := &file_plugin_proto_msgTypes[73]
+	mi := &file_plugin_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4625,7 +5249,7 @@ func (x *NodeWithToken) String() string {
 func (*NodeWithToken) ProtoMessage() {}
 
 func (x *NodeWithToken) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[73]
+	mi := &file_plugin_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4638,7 +5262,7 @@ func (x *NodeWithToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeWithToken.ProtoReflect.Descriptor instead.
 func (*NodeWithToken) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{73}
+	return file_plugin_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *NodeWithToken) GetNode() *Node {
@@ -4665,7 +5289,7 @@ type NodeToken struct {
 
 func (x *NodeToken) Reset() {
 	*x = NodeToken{}
-	mi := &file_plugin_proto_msgTypes[74]
+	mi := &file_plugin_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4677,7 +5301,7 @@ func (x *NodeToken) String() string {
 func (*NodeToken) ProtoMessage() {}
 
 func (x *NodeToken) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[74]
+	mi := &file_plugin_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4690,7 +5314,7 @@ func (x *NodeToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeToken.ProtoReflect.Descriptor instead.
 func (*NodeToken) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{74}
+	return file_plugin_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *NodeToken) GetTokenId() string {
@@ -4727,7 +5351,7 @@ type Package struct {
 
 func (x *Package) Reset() {
 	*x = Package{}
-	mi := &file_plugin_proto_msgTypes[75]
+	mi := &file_plugin_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4739,7 +5363,7 @@ func (x *Package) String() string {
 func (*Package) ProtoMessage() {}
 
 func (x *Package) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[75]
+	mi := &file_plugin_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4752,7 +5376,7 @@ func (x *Package) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Package.ProtoReflect.Descriptor instead.
 func (*Package) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{75}
+	return file_plugin_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *Package) GetId() string {
@@ -4841,7 +5465,7 @@ type ListPackagesResponse struct {
 
 func (x *ListPackagesResponse) Reset() {
 	*x = ListPackagesResponse{}
-	mi := &file_plugin_proto_msgTypes[76]
+	mi := &file_plugin_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4853,7 +5477,7 @@ func (x *ListPackagesResponse) String() string {
 func (*ListPackagesResponse) ProtoMessage() {}
 
 func (x *ListPackagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[76]
+	mi := &file_plugin_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4866,7 +5490,7 @@ func (x *ListPackagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPackagesResponse.ProtoReflect.Descriptor instead.
 func (*ListPackagesResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{76}
+	return file_plugin_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *ListPackagesResponse) GetPackages() []*Package {
@@ -4894,7 +5518,7 @@ type CreatePackageRequest struct {
 
 func (x *CreatePackageRequest) Reset() {
 	*x = CreatePackageRequest{}
-	mi := &file_plugin_proto_msgTypes[77]
+	mi := &file_plugin_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4906,7 +5530,7 @@ func (x *CreatePackageRequest) String() string {
 func (*CreatePackageRequest) ProtoMessage() {}
 
 func (x *CreatePackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[77]
+	mi := &file_plugin_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4919,7 +5543,7 @@ func (x *CreatePackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePackageRequest.ProtoReflect.Descriptor instead.
 func (*CreatePackageRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{77}
+	return file_plugin_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *CreatePackageRequest) GetName() string {
@@ -5011,7 +5635,7 @@ type UpdatePackageRequest struct {
 
 func (x *UpdatePackageRequest) Reset() {
 	*x = UpdatePackageRequest{}
-	mi := &file_plugin_proto_msgTypes[78]
+	mi := &file_plugin_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5023,7 +5647,7 @@ func (x *UpdatePackageRequest) String() string {
 func (*UpdatePackageRequest) ProtoMessage() {}
 
 func (x *UpdatePackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[78]
+	mi := &file_plugin_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5036,7 +5660,7 @@ func (x *UpdatePackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePackageRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePackageRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{78}
+	return file_plugin_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *UpdatePackageRequest) GetId() string {
@@ -5129,7 +5753,7 @@ type IPBan struct {
 
 func (x *IPBan) Reset() {
 	*x = IPBan{}
-	mi := &file_plugin_proto_msgTypes[79]
+	mi := &file_plugin_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5141,7 +5765,7 @@ func (x *IPBan) String() string {
 func (*IPBan) ProtoMessage() {}
 
 func (x *IPBan) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[79]
+	mi := &file_plugin_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5154,7 +5778,7 @@ func (x *IPBan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IPBan.ProtoReflect.Descriptor instead.
 func (*IPBan) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{79}
+	return file_plugin_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *IPBan) GetId() string {
@@ -5194,7 +5818,7 @@ type ListIPBansResponse struct {
 
 func (x *ListIPBansResponse) Reset() {
 	*x = ListIPBansResponse{}
-	mi := &file_plugin_proto_msgTypes[80]
+	mi := &file_plugin_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5206,7 +5830,7 @@ func (x *ListIPBansResponse) String() string {
 func (*ListIPBansResponse) ProtoMessage() {}
 
 func (x *ListIPBansResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[80]
+	mi := &file_plugin_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5219,7 +5843,7 @@ func (x *ListIPBansResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListIPBansResponse.ProtoReflect.Descriptor instead.
 func (*ListIPBansResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{80}
+	return file_plugin_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *ListIPBansResponse) GetBans() []*IPBan {
@@ -5239,7 +5863,7 @@ type CreateIPBanRequest struct {
 
 func (x *CreateIPBanRequest) Reset() {
 	*x = CreateIPBanRequest{}
-	mi := &file_plugin_proto_msgTypes[81]
+	mi := &file_plugin_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5251,7 +5875,7 @@ func (x *CreateIPBanRequest) String() string {
 func (*CreateIPBanRequest) ProtoMessage() {}
 
 func (x *CreateIPBanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[81]
+	mi := &file_plugin_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5264,7 +5888,7 @@ func (x *CreateIPBanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateIPBanRequest.ProtoReflect.Descriptor instead.
 func (*CreateIPBanRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{81}
+	return file_plugin_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *CreateIPBanRequest) GetIp() string {
@@ -5292,7 +5916,7 @@ type Settings struct {
 
 func (x *Settings) Reset() {
 	*x = Settings{}
-	mi := &file_plugin_proto_msgTypes[82]
+	mi := &file_plugin_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5304,7 +5928,7 @@ func (x *Settings) String() string {
 func (*Settings) ProtoMessage() {}
 
 func (x *Settings) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[82]
+	mi := &file_plugin_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5317,7 +5941,7 @@ func (x *Settings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Settings.ProtoReflect.Descriptor instead.
 func (*Settings) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{82}
+	return file_plugin_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *Settings) GetRegistrationEnabled() bool {
@@ -5351,7 +5975,7 @@ type ActivityLog struct {
 
 func (x *ActivityLog) Reset() {
 	*x = ActivityLog{}
-	mi := &file_plugin_proto_msgTypes[83]
+	mi := &file_plugin_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5363,7 +5987,7 @@ func (x *ActivityLog) String() string {
 func (*ActivityLog) ProtoMessage() {}
 
 func (x *ActivityLog) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[83]
+	mi := &file_plugin_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5376,7 +6000,7 @@ func (x *ActivityLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivityLog.ProtoReflect.Descriptor instead.
 func (*ActivityLog) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{83}
+	return file_plugin_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *ActivityLog) GetId() string {
@@ -5447,7 +6071,7 @@ type GetLogsRequest struct {
 
 func (x *GetLogsRequest) Reset() {
 	*x = GetLogsRequest{}
-	mi := &file_plugin_proto_msgTypes[84]
+	mi := &file_plugin_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5459,7 +6083,7 @@ func (x *GetLogsRequest) String() string {
 func (*GetLogsRequest) ProtoMessage() {}
 
 func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[84]
+	mi := &file_plugin_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5472,7 +6096,7 @@ func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetLogsRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{84}
+	return file_plugin_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *GetLogsRequest) GetLimit() int32 {
@@ -5513,7 +6137,7 @@ type GetLogsResponse struct {
 
 func (x *GetLogsResponse) Reset() {
 	*x = GetLogsResponse{}
-	mi := &file_plugin_proto_msgTypes[85]
+	mi := &file_plugin_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5525,7 +6149,7 @@ func (x *GetLogsResponse) String() string {
 func (*GetLogsResponse) ProtoMessage() {}
 
 func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[85]
+	mi := &file_plugin_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5538,7 +6162,7 @@ func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetLogsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{85}
+	return file_plugin_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *GetLogsResponse) GetLogs() []*ActivityLog {
@@ -5566,7 +6190,7 @@ type LogRequest struct {
 
 func (x *LogRequest) Reset() {
 	*x = LogRequest{}
-	mi := &file_plugin_proto_msgTypes[86]
+	mi := &file_plugin_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5578,7 +6202,7 @@ func (x *LogRequest) String() string {
 func (*LogRequest) ProtoMessage() {}
 
 func (x *LogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[86]
+	mi := &file_plugin_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5591,7 +6215,7 @@ func (x *LogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{86}
+	return file_plugin_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *LogRequest) GetLevel() string {
@@ -5617,7 +6241,7 @@ type KVRequest struct {
 
 func (x *KVRequest) Reset() {
 	*x = KVRequest{}
-	mi := &file_plugin_proto_msgTypes[87]
+	mi := &file_plugin_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5629,7 +6253,7 @@ func (x *KVRequest) String() string {
 func (*KVRequest) ProtoMessage() {}
 
 func (x *KVRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[87]
+	mi := &file_plugin_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5642,7 +6266,7 @@ func (x *KVRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVRequest.ProtoReflect.Descriptor instead.
 func (*KVRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{87}
+	return file_plugin_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *KVRequest) GetKey() string {
@@ -5662,7 +6286,7 @@ type KVResponse struct {
 
 func (x *KVResponse) Reset() {
 	*x = KVResponse{}
-	mi := &file_plugin_proto_msgTypes[88]
+	mi := &file_plugin_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5674,7 +6298,7 @@ func (x *KVResponse) String() string {
 func (*KVResponse) ProtoMessage() {}
 
 func (x *KVResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[88]
+	mi := &file_plugin_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5687,7 +6311,7 @@ func (x *KVResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVResponse.ProtoReflect.Descriptor instead.
 func (*KVResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{88}
+	return file_plugin_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *KVResponse) GetValue() string {
@@ -5714,7 +6338,7 @@ type KVSetRequest struct {
 
 func (x *KVSetRequest) Reset() {
 	*x = KVSetRequest{}
-	mi := &file_plugin_proto_msgTypes[89]
+	mi := &file_plugin_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5726,7 +6350,7 @@ func (x *KVSetRequest) String() string {
 func (*KVSetRequest) ProtoMessage() {}
 
 func (x *KVSetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[89]
+	mi := &file_plugin_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5739,7 +6363,7 @@ func (x *KVSetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVSetRequest.ProtoReflect.Descriptor instead.
 func (*KVSetRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{89}
+	return file_plugin_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *KVSetRequest) GetKey() string {
@@ -5756,17 +6380,181 @@ func (x *KVSetRequest) GetValue() string {
 	return ""
 }
 
+type QueryArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*QueryArg_StringValue
+	//	*QueryArg_IntValue
+	//	*QueryArg_FloatValue
+	//	*QueryArg_BoolValue
+	//	*QueryArg_TimeValue
+	//	*QueryArg_BytesValue
+	//	*QueryArg_NullValue
+	Value         isQueryArg_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryArg) Reset() {
+	*x = QueryArg{}
+	mi := &file_plugin_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryArg) ProtoMessage() {}
+
+func (x *QueryArg) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryArg.ProtoReflect.Descriptor instead.
+func (*QueryArg) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *QueryArg) GetValue() isQueryArg_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *QueryArg) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *QueryArg) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *QueryArg) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *QueryArg) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *QueryArg) GetTimeValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_TimeValue); ok {
+			return x.TimeValue
+		}
+	}
+	return 0
+}
+
+func (x *QueryArg) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *QueryArg) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*QueryArg_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+type isQueryArg_Value interface {
+	isQueryArg_Value()
+}
+
+type QueryArg_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type QueryArg_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type QueryArg_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type QueryArg_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type QueryArg_TimeValue struct {
+	TimeValue int64 `protobuf:"varint,5,opt,name=time_value,json=timeValue,proto3,oneof"`
+}
+
+type QueryArg_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,6,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type QueryArg_NullValue struct {
+	NullValue bool `protobuf:"varint,7,opt,name=null_value,json=nullValue,proto3,oneof"`
+}
+
+func (*QueryArg_StringValue) isQueryArg_Value() {}
+
+func (*QueryArg_IntValue) isQueryArg_Value() {}
+
+func (*QueryArg_FloatValue) isQueryArg_Value() {}
+
+func (*QueryArg_BoolValue) isQueryArg_Value() {}
+
+func (*QueryArg_TimeValue) isQueryArg_Value() {}
+
+func (*QueryArg_BytesValue) isQueryArg_Value() {}
+
+func (*QueryArg_NullValue) isQueryArg_Value() {}
+
 type QueryDBRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	TypedArgs     []*QueryArg            `protobuf:"bytes,3,rep,name=typed_args,json=typedArgs,proto3" json:"typed_args,omitempty"`
+	Exec          bool                   `protobuf:"varint,4,opt,name=exec,proto3" json:"exec,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryDBRequest) Reset() {
 	*x = QueryDBRequest{}
-	mi := &file_plugin_proto_msgTypes[90]
+	mi := &file_plugin_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5778,7 +6566,7 @@ func (x *QueryDBRequest) String() string {
 func (*QueryDBRequest) ProtoMessage() {}
 
 func (x *QueryDBRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[90]
+	mi := &file_plugin_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5791,7 +6579,7 @@ func (x *QueryDBRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDBRequest.ProtoReflect.Descriptor instead.
 func (*QueryDBRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{90}
+	return file_plugin_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *QueryDBRequest) GetQuery() string {
@@ -5808,16 +6596,32 @@ func (x *QueryDBRequest) GetArgs() []string {
 	return nil
 }
 
+func (x *QueryDBRequest) GetTypedArgs() []*QueryArg {
+	if x != nil {
+		return x.TypedArgs
+	}
+	return nil
+}
+
+func (x *QueryDBRequest) GetExec() bool {
+	if x != nil {
+		return x.Exec
+	}
+	return false
+}
+
 type QueryDBResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Rows          [][]byte               `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	RowsAffected  int64                  `protobuf:"varint,2,opt,name=rows_affected,json=rowsAffected,proto3" json:"rows_affected,omitempty"`
+	LastInsertId  int64                  `protobuf:"varint,3,opt,name=last_insert_id,json=lastInsertId,proto3" json:"last_insert_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryDBResponse) Reset() {
 	*x = QueryDBResponse{}
-	mi := &file_plugin_proto_msgTypes[91]
+	mi := &file_plugin_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5829,7 +6633,7 @@ func (x *QueryDBResponse) String() string {
 func (*QueryDBResponse) ProtoMessage() {}
 
 func (x *QueryDBResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[91]
+	mi := &file_plugin_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5842,7 +6646,7 @@ func (x *QueryDBResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDBResponse.ProtoReflect.Descriptor instead.
 func (*QueryDBResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{91}
+	return file_plugin_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *QueryDBResponse) GetRows() [][]byte {
@@ -5852,6 +6656,20 @@ func (x *QueryDBResponse) GetRows() [][]byte {
 	return nil
 }
 
+func (x *QueryDBResponse) GetRowsAffected() int64 {
+	if x != nil {
+		return x.RowsAffected
+	}
+	return 0
+}
+
+func (x *QueryDBResponse) GetLastInsertId() int64 {
+	if x != nil {
+		return x.LastInsertId
+	}
+	return 0
+}
+
 type BroadcastEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
@@ -5862,7 +6680,7 @@ type BroadcastEventRequest struct {
 
 func (x *BroadcastEventRequest) Reset() {
 	*x = BroadcastEventRequest{}
-	mi := &file_plugin_proto_msgTypes[92]
+	mi := &file_plugin_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5874,7 +6692,7 @@ func (x *BroadcastEventRequest) String() string {
 func (*BroadcastEventRequest) ProtoMessage() {}
 
 func (x *BroadcastEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[92]
+	mi := &file_plugin_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5887,7 +6705,7 @@ func (x *BroadcastEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BroadcastEventRequest.ProtoReflect.Descriptor instead.
 func (*BroadcastEventRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{92}
+	return file_plugin_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *BroadcastEventRequest) GetEventType() string {
@@ -5916,7 +6734,7 @@ type NotificationRequest struct {
 
 func (x *NotificationRequest) Reset() {
 	*x = NotificationRequest{}
-	mi := &file_plugin_proto_msgTypes[93]
+	mi := &file_plugin_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5928,7 +6746,7 @@ func (x *NotificationRequest) String() string {
 func (*NotificationRequest) ProtoMessage() {}
 
 func (x *NotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[93]
+	mi := &file_plugin_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5941,7 +6759,7 @@ func (x *NotificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationRequest.ProtoReflect.Descriptor instead.
 func (*NotificationRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{93}
+	return file_plugin_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *NotificationRequest) GetUserId() string {
@@ -5986,7 +6804,7 @@ type PluginHTTPRequest struct {
 
 func (x *PluginHTTPRequest) Reset() {
 	*x = PluginHTTPRequest{}
-	mi := &file_plugin_proto_msgTypes[94]
+	mi := &file_plugin_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5998,7 +6816,7 @@ func (x *PluginHTTPRequest) String() string {
 func (*PluginHTTPRequest) ProtoMessage() {}
 
 func (x *PluginHTTPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[94]
+	mi := &file_plugin_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6011,7 +6829,7 @@ func (x *PluginHTTPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginHTTPRequest.ProtoReflect.Descriptor instead.
 func (*PluginHTTPRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{94}
+	return file_plugin_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *PluginHTTPRequest) GetMethod() string {
@@ -6061,7 +6879,7 @@ type PluginHTTPResponse struct {
 
 func (x *PluginHTTPResponse) Reset() {
 	*x = PluginHTTPResponse{}
-	mi := &file_plugin_proto_msgTypes[95]
+	mi := &file_plugin_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6073,7 +6891,7 @@ func (x *PluginHTTPResponse) String() string {
 func (*PluginHTTPResponse) ProtoMessage() {}
 
 func (x *PluginHTTPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[95]
+	mi := &file_plugin_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6086,7 +6904,7 @@ func (x *PluginHTTPResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginHTTPResponse.ProtoReflect.Descriptor instead.
 func (*PluginHTTPResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{95}
+	return file_plugin_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *PluginHTTPResponse) GetStatus() int32 {
@@ -6129,7 +6947,7 @@ type CallPluginRequest struct {
 
 func (x *CallPluginRequest) Reset() {
 	*x = CallPluginRequest{}
-	mi := &file_plugin_proto_msgTypes[96]
+	mi := &file_plugin_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6141,7 +6959,7 @@ func (x *CallPluginRequest) String() string {
 func (*CallPluginRequest) ProtoMessage() {}
 
 func (x *CallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[96]
+	mi := &file_plugin_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6154,7 +6972,7 @@ func (x *CallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallPluginRequest.ProtoReflect.Descriptor instead.
 func (*CallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{96}
+	return file_plugin_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *CallPluginRequest) GetPluginId() string {
@@ -6188,7 +7006,7 @@ type CallPluginResponse struct {
 
 func (x *CallPluginResponse) Reset() {
 	*x = CallPluginResponse{}
-	mi := &file_plugin_proto_msgTypes[97]
+	mi := &file_plugin_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6200,7 +7018,7 @@ func (x *CallPluginResponse) String() string {
 func (*CallPluginResponse) ProtoMessage() {}
 
 func (x *CallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[97]
+	mi := &file_plugin_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6213,7 +7031,7 @@ func (x *CallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallPluginResponse.ProtoReflect.Descriptor instead.
 func (*CallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{97}
+	return file_plugin_proto_rawDescGZIP(), []int{106}
 }
 
 func (x *CallPluginResponse) GetData() []byte {
@@ -6557,13 +7375,45 @@ const file_plugin_proto_rawDesc = "" +
 	"\x0fMoveFileRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
 	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
-	"\x02to\x18\x03 \x01(\tR\x02to\"_\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"}\n" +
+	"\x13DownloadFileRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x04 \x01(\x05R\tchunkSize\"\xce\x01\n" +
+	"\tFileChunk\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tupload_id\x18\x03 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x10\n" +
+	"\x03eof\x18\x06 \x01(\bR\x03eof\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\a \x01(\x03R\ttotalSize\x12\x16\n" +
+	"\x06sha256\x18\b \x01(\tR\x06sha256\"]\n" +
+	"\x12UploadFileResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"O\n" +
+	"\x13UploadStatusRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tupload_id\x18\x02 \x01(\tR\buploadId\"w\n" +
+	"\fUploadStatus\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1a\n" +
+	"\breceived\x18\x03 \x01(\x03R\breceived\x12\x1a\n" +
+	"\bcomplete\x18\x04 \x01(\bR\bcomplete\"\xe0\x01\n" +
 	"\x06Backup\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt\"@\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\x12 \n" +
+	"\vcompression\x18\x06 \x01(\tR\vcompression\x12\x14\n" +
+	"\x05files\x18\a \x03(\tR\x05files\x12+\n" +
+	"\x11uncompressed_size\x18\b \x01(\x03R\x10uncompressedSize\"@\n" +
 	"\x13ListBackupsResponse\x12)\n" +
 	"\abackups\x18\x01 \x03(\v2\x0f.plugins.BackupR\abackups\"F\n" +
 	"\x13CreateBackupRequest\x12\x1b\n" +
@@ -6571,14 +7421,40 @@ const file_plugin_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"O\n" +
 	"\x13DeleteBackupRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
-	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\"\x96\x01\n" +
+	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\"I\n" +
+	"\rBackupRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\"l\n" +
+	"\x14RestoreBackupRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\x12\x1a\n" +
+	"\btruncate\x18\x03 \x01(\bR\btruncate\"\x88\x01\n" +
+	"\x15DownloadBackupRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x04 \x01(\x05R\tchunkSize\"\xfd\x03\n" +
 	"\x04Node\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04fqdn\x18\x03 \x01(\tR\x04fqdn\x12\x12\n" +
 	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x1b\n" +
 	"\tis_online\x18\x05 \x01(\bR\bisOnline\x12%\n" +
-	"\x0elast_heartbeat\x18\x06 \x01(\tR\rlastHeartbeat\"8\n" +
+	"\x0elast_heartbeat\x18\x06 \x01(\tR\rlastHeartbeat\x12!\n" +
+	"\fmemory_total\x18\a \x01(\x03R\vmemoryTotal\x12)\n" +
+	"\x10memory_allocated\x18\b \x01(\x03R\x0fmemoryAllocated\x12\x1d\n" +
+	"\n" +
+	"disk_total\x18\t \x01(\x03R\tdiskTotal\x12%\n" +
+	"\x0edisk_allocated\x18\n" +
+	" \x01(\x03R\rdiskAllocated\x12\x1b\n" +
+	"\tcpu_total\x18\v \x01(\x03R\bcpuTotal\x12#\n" +
+	"\rcpu_allocated\x18\f \x01(\x03R\fcpuAllocated\x12!\n" +
+	"\fserver_count\x18\r \x01(\x05R\vserverCount\x121\n" +
+	"\x06labels\x18\x0e \x03(\v2\x19.plugins.Node.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"8\n" +
 	"\x11ListNodesResponse\x12#\n" +
 	"\x05nodes\x18\x01 \x03(\v2\r.plugins.NodeR\x05nodes\"O\n" +
 	"\x11CreateNodeRequest\x12\x12\n" +
@@ -6678,12 +7554,31 @@ const file_plugin_proto_rawDesc = "" +
 	"\x05found\x18\x02 \x01(\bR\x05found\"6\n" +
 	"\fKVSetRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\":\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x80\x02\n" +
+	"\bQueryArg\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12!\n" +
+	"\vfloat_value\x18\x03 \x01(\x01H\x00R\n" +
+	"floatValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x04 \x01(\bH\x00R\tboolValue\x12\x1f\n" +
+	"\n" +
+	"time_value\x18\x05 \x01(\x03H\x00R\ttimeValue\x12!\n" +
+	"\vbytes_value\x18\x06 \x01(\fH\x00R\n" +
+	"bytesValue\x12\x1f\n" +
+	"\n" +
+	"null_value\x18\a \x01(\bH\x00R\tnullValueB\a\n" +
+	"\x05value\"\x80\x01\n" +
 	"\x0eQueryDBRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
-	"\x04args\x18\x02 \x03(\tR\x04args\"%\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x120\n" +
+	"\n" +
+	"typed_args\x18\x03 \x03(\v2\x11.plugins.QueryArgR\ttypedArgs\x12\x12\n" +
+	"\x04exec\x18\x04 \x01(\bR\x04exec\"p\n" +
 	"\x0fQueryDBResponse\x12\x12\n" +
-	"\x04rows\x18\x01 \x03(\fR\x04rows\"\xad\x01\n" +
+	"\x04rows\x18\x01 \x03(\fR\x04rows\x12#\n" +
+	"\rrows_affected\x18\x02 \x01(\x03R\frowsAffected\x12$\n" +
+	"\x0elast_insert_id\x18\x03 \x01(\x03R\flastInsertId\"\xad\x01\n" +
 	"\x15BroadcastEventRequest\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x01 \x01(\tR\teventType\x12<\n" +
@@ -6727,7 +7622,7 @@ const file_plugin_proto_rawDesc = "" +
 	"\n" +
 	"OnSchedule\x12\x18.plugins.ScheduleRequest\x1a\x0e.plugins.Empty\x128\n" +
 	"\aOnMixin\x12\x15.plugins.MixinRequest\x1a\x16.plugins.MixinResponse\x12*\n" +
-	"\bShutdown\x12\x0e.plugins.Empty\x1a\x0e.plugins.Empty2\xb4)\n" +
+	"\bShutdown\x12\x0e.plugins.Empty\x1a\x0e.plugins.Empty2\xbf,\n" +
 	"\fPanelService\x120\n" +
 	"\tGetServer\x12\x12.plugins.IDRequest\x1a\x0f.plugins.Server\x12H\n" +
 	"\vListServers\x12\x1b.plugins.ListServersRequest\x1a\x1c.plugins.ListServersResponse\x12=\n" +
@@ -6796,10 +7691,17 @@ const file_plugin_proto_rawDesc = "" +
 	"\bMoveFile\x12\x18.plugins.MoveFileRequest\x1a\x0e.plugins.Empty\x124\n" +
 	"\bCopyFile\x12\x18.plugins.MoveFileRequest\x1a\x0e.plugins.Empty\x129\n" +
 	"\rCompressFiles\x12\x18.plugins.CompressRequest\x1a\x0e.plugins.Empty\x12:\n" +
-	"\x0eDecompressFile\x12\x18.plugins.FilePathRequest\x1a\x0e.plugins.Empty\x12?\n" +
+	"\x0eDecompressFile\x12\x18.plugins.FilePathRequest\x1a\x0e.plugins.Empty\x12B\n" +
+	"\fDownloadFile\x12\x1c.plugins.DownloadFileRequest\x1a\x12.plugins.FileChunk0\x01\x12?\n" +
+	"\n" +
+	"UploadFile\x12\x12.plugins.FileChunk\x1a\x1b.plugins.UploadFileResponse(\x01\x12F\n" +
+	"\x0fGetUploadStatus\x12\x1c.plugins.UploadStatusRequest\x1a\x15.plugins.UploadStatus\x12?\n" +
 	"\vListBackups\x12\x12.plugins.IDRequest\x1a\x1c.plugins.ListBackupsResponse\x12<\n" +
 	"\fCreateBackup\x12\x1c.plugins.CreateBackupRequest\x1a\x0e.plugins.Empty\x12<\n" +
-	"\fDeleteBackup\x12\x1c.plugins.DeleteBackupRequest\x1a\x0e.plugins.Empty\x127\n" +
+	"\fDeleteBackup\x12\x1c.plugins.DeleteBackupRequest\x1a\x0e.plugins.Empty\x124\n" +
+	"\tGetBackup\x12\x16.plugins.BackupRequest\x1a\x0f.plugins.Backup\x12>\n" +
+	"\rRestoreBackup\x12\x1d.plugins.RestoreBackupRequest\x1a\x0e.plugins.Empty\x12F\n" +
+	"\x0eDownloadBackup\x12\x1e.plugins.DownloadBackupRequest\x1a\x12.plugins.FileChunk0\x01\x127\n" +
 	"\tListNodes\x12\x0e.plugins.Empty\x1a\x1a.plugins.ListNodesResponse\x12,\n" +
 	"\aGetNode\x12\x12.plugins.IDRequest\x1a\r.plugins.Node\x12@\n" +
 	"\n" +
@@ -6846,7 +7748,7 @@ func file_plugin_proto_rawDescGZIP() []byte {
 }
 
 var file_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 106)
+var file_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 116)
 var file_plugin_proto_goTypes = []any{
 	(MixinResponse_Action)(0),         // 0: plugins.MixinResponse.Action
 	(*Empty)(nil),                     // 1: plugins.Empty
@@ -6915,46 +7817,56 @@ var file_plugin_proto_goTypes = []any{
 	(*FileContent)(nil),               // 64: plugins.FileContent
 	(*WriteFileRequest)(nil),          // 65: plugins.WriteFileRequest
 	(*MoveFileRequest)(nil),           // 66: plugins.MoveFileRequest
-	(*Backup)(nil),                    // 67: plugins.Backup
-	(*ListBackupsResponse)(nil),       // 68: plugins.ListBackupsResponse
-	(*CreateBackupRequest)(nil),       // 69: plugins.CreateBackupRequest
-	(*DeleteBackupRequest)(nil),       // 70: plugins.DeleteBackupRequest
-	(*Node)(nil),                      // 71: plugins.Node
-	(*ListNodesResponse)(nil),         // 72: plugins.ListNodesResponse
-	(*CreateNodeRequest)(nil),         // 73: plugins.CreateNodeRequest
-	(*NodeWithToken)(nil),             // 74: plugins.NodeWithToken
-	(*NodeToken)(nil),                 // 75: plugins.NodeToken
-	(*Package)(nil),                   // 76: plugins.Package
-	(*ListPackagesResponse)(nil),      // 77: plugins.ListPackagesResponse
-	(*CreatePackageRequest)(nil),      // 78: plugins.CreatePackageRequest
-	(*UpdatePackageRequest)(nil),      // 79: plugins.UpdatePackageRequest
-	(*IPBan)(nil),                     // 80: plugins.IPBan
-	(*ListIPBansResponse)(nil),        // 81: plugins.ListIPBansResponse
-	(*CreateIPBanRequest)(nil),        // 82: plugins.CreateIPBanRequest
-	(*Settings)(nil),                  // 83: plugins.Settings
-	(*ActivityLog)(nil),               // 84: plugins.ActivityLog
-	(*GetLogsRequest)(nil),            // 85: plugins.GetLogsRequest
-	(*GetLogsResponse)(nil),           // 86: plugins.GetLogsResponse
-	(*LogRequest)(nil),                // 87: plugins.LogRequest
-	(*KVRequest)(nil),                 // 88: plugins.KVRequest
-	(*KVResponse)(nil),                // 89: plugins.KVResponse
-	(*KVSetRequest)(nil),              // 90: plugins.KVSetRequest
-	(*QueryDBRequest)(nil),            // 91: plugins.QueryDBRequest
-	(*QueryDBResponse)(nil),           // 92: plugins.QueryDBResponse
-	(*BroadcastEventRequest)(nil),     // 93: plugins.BroadcastEventRequest
-	(*NotificationRequest)(nil),       // 94: plugins.NotificationRequest
-	(*PluginHTTPRequest)(nil),         // 95: plugins.PluginHTTPRequest
-	(*PluginHTTPResponse)(nil),        // 96: plugins.PluginHTTPResponse
-	(*CallPluginRequest)(nil),         // 97: plugins.CallPluginRequest
-	(*CallPluginResponse)(nil),        // 98: plugins.CallPluginResponse
-	nil,                               // 99: plugins.Event.DataEntry
-	nil,                               // 100: plugins.HTTPRequest.HeadersEntry
-	nil,                               // 101: plugins.HTTPRequest.QueryEntry
-	nil,                               // 102: plugins.HTTPResponse.HeadersEntry
-	nil,                               // 103: plugins.UpdateVariablesRequest.VariablesEntry
-	nil,                               // 104: plugins.BroadcastEventRequest.DataEntry
-	nil,                               // 105: plugins.PluginHTTPRequest.HeadersEntry
-	nil,                               // 106: plugins.PluginHTTPResponse.HeadersEntry
+	(*DownloadFileRequest)(nil),       // 67: plugins.DownloadFileRequest
+	(*FileChunk)(nil),                 // 68: plugins.FileChunk
+	(*UploadFileResponse)(nil),        // 69: plugins.UploadFileResponse
+	(*UploadStatusRequest)(nil),       // 70: plugins.UploadStatusRequest
+	(*UploadStatus)(nil),              // 71: plugins.UploadStatus
+	(*Backup)(nil),                    // 72: plugins.Backup
+	(*ListBackupsResponse)(nil),       // 73: plugins.ListBackupsResponse
+	(*CreateBackupRequest)(nil),       // 74: plugins.CreateBackupRequest
+	(*DeleteBackupRequest)(nil),       // 75: plugins.DeleteBackupRequest
+	(*BackupRequest)(nil),             // 76: plugins.BackupRequest
+	(*RestoreBackupRequest)(nil),      // 77: plugins.RestoreBackupRequest
+	(*DownloadBackupRequest)(nil),     // 78: plugins.DownloadBackupRequest
+	(*Node)(nil),                      // 79: plugins.Node
+	(*ListNodesResponse)(nil),         // 80: plugins.ListNodesResponse
+	(*CreateNodeRequest)(nil),         // 81: plugins.CreateNodeRequest
+	(*NodeWithToken)(nil),             // 82: plugins.NodeWithToken
+	(*NodeToken)(nil),                 // 83: plugins.NodeToken
+	(*Package)(nil),                   // 84: plugins.Package
+	(*ListPackagesResponse)(nil),      // 85: plugins.ListPackagesResponse
+	(*CreatePackageRequest)(nil),      // 86: plugins.CreatePackageRequest
+	(*UpdatePackageRequest)(nil),      // 87: plugins.UpdatePackageRequest
+	(*IPBan)(nil),                     // 88: plugins.IPBan
+	(*ListIPBansResponse)(nil),        // 89: plugins.ListIPBansResponse
+	(*CreateIPBanRequest)(nil),        // 90: plugins.CreateIPBanRequest
+	(*Settings)(nil),                  // 91: plugins.Settings
+	(*ActivityLog)(nil),               // 92: plugins.ActivityLog
+	(*GetLogsRequest)(nil),            // 93: plugins.GetLogsRequest
+	(*GetLogsResponse)(nil),           // 94: plugins.GetLogsResponse
+	(*LogRequest)(nil),                // 95: plugins.LogRequest
+	(*KVRequest)(nil),                 // 96: plugins.KVRequest
+	(*KVResponse)(nil),                // 97: plugins.KVResponse
+	(*KVSetRequest)(nil),              // 98: plugins.KVSetRequest
+	(*QueryArg)(nil),                  // 99: plugins.QueryArg
+	(*QueryDBRequest)(nil),            // 100: plugins.QueryDBRequest
+	(*QueryDBResponse)(nil),           // 101: plugins.QueryDBResponse
+	(*BroadcastEventRequest)(nil),     // 102: plugins.BroadcastEventRequest
+	(*NotificationRequest)(nil),       // 103: plugins.NotificationRequest
+	(*PluginHTTPRequest)(nil),         // 104: plugins.PluginHTTPRequest
+	(*PluginHTTPResponse)(nil),        // 105: plugins.PluginHTTPResponse
+	(*CallPluginRequest)(nil),         // 106: plugins.CallPluginRequest
+	(*CallPluginResponse)(nil),        // 107: plugins.CallPluginResponse
+	nil,                               // 108: plugins.Event.DataEntry
+	nil,                               // 109: plugins.HTTPRequest.HeadersEntry
+	nil,                               // 110: plugins.HTTPRequest.QueryEntry
+	nil,                               // 111: plugins.HTTPResponse.HeadersEntry
+	nil,                               // 112: plugins.UpdateVariablesRequest.VariablesEntry
+	nil,                               // 113: plugins.Node.LabelsEntry
+	nil,                               // 114: plugins.BroadcastEventRequest.DataEntry
+	nil,                               // 115: plugins.PluginHTTPRequest.HeadersEntry
+	nil,                               // 116: plugins.PluginHTTPResponse.HeadersEntry
 }
 var file_plugin_proto_depIdxs = []int32{
 	14,  // 0: plugins.PluginInfo.routes:type_name -> plugins.RouteInfo
@@ -6965,12 +7877,12 @@ var file_plugin_proto_depIdxs = []int32{
 	9,   // 5: plugins.PluginUIInfo.pages:type_name -> plugins.PluginPageInfo
 	0,   // 6: plugins.MixinResponse.action:type_name -> plugins.MixinResponse.Action
 	13,  // 7: plugins.MixinResponse.notifications:type_name -> plugins.Notification
-	99,  // 8: plugins.Event.data:type_name -> plugins.Event.DataEntry
-	100, // 9: plugins.HTTPRequest.headers:type_name -> plugins.HTTPRequest.HeadersEntry
-	101, // 10: plugins.HTTPRequest.query:type_name -> plugins.HTTPRequest.QueryEntry
-	102, // 11: plugins.HTTPResponse.headers:type_name -> plugins.HTTPResponse.HeadersEntry
+	108, // 8: plugins.Event.data:type_name -> plugins.Event.DataEntry
+	109, // 9: plugins.HTTPRequest.headers:type_name -> plugins.HTTPRequest.HeadersEntry
+	110, // 10: plugins.HTTPRequest.query:type_name -> plugins.HTTPRequest.QueryEntry
+	111, // 11: plugins.HTTPResponse.headers:type_name -> plugins.HTTPResponse.HeadersEntry
 	21,  // 12: plugins.ListServersResponse.servers:type_name -> plugins.Server
-	103, // 13: plugins.UpdateVariablesRequest.variables:type_name -> plugins.UpdateVariablesRequest.VariablesEntry
+	112, // 13: plugins.UpdateVariablesRequest.variables:type_name -> plugins.UpdateVariablesRequest.VariablesEntry
 	39,  // 14: plugins.SearchLogsResponse.matches:type_name -> plugins.LogMatch
 	41,  // 15: plugins.LogFilesResponse.files:type_name -> plugins.LogFileInfo
 	43,  // 16: plugins.ListUsersResponse.users:type_name -> plugins.User
@@ -6978,208 +7890,222 @@ var file_plugin_proto_depIdxs = []int32{
 	54,  // 18: plugins.ListDatabasesResponse.databases:type_name -> plugins.Database
 	57,  // 19: plugins.ListDatabaseHostsResponse.hosts:type_name -> plugins.DatabaseHost
 	61,  // 20: plugins.ListFilesResponse.files:type_name -> plugins.FileInfo
-	67,  // 21: plugins.ListBackupsResponse.backups:type_name -> plugins.Backup
-	71,  // 22: plugins.ListNodesResponse.nodes:type_name -> plugins.Node
-	71,  // 23: plugins.NodeWithToken.node:type_name -> plugins.Node
-	76,  // 24: plugins.ListPackagesResponse.packages:type_name -> plugins.Package
-	80,  // 25: plugins.ListIPBansResponse.bans:type_name -> plugins.IPBan
-	84,  // 26: plugins.GetLogsResponse.logs:type_name -> plugins.ActivityLog
-	104, // 27: plugins.BroadcastEventRequest.data:type_name -> plugins.BroadcastEventRequest.DataEntry
-	105, // 28: plugins.PluginHTTPRequest.headers:type_name -> plugins.PluginHTTPRequest.HeadersEntry
-	106, // 29: plugins.PluginHTTPResponse.headers:type_name -> plugins.PluginHTTPResponse.HeadersEntry
-	1,   // 30: plugins.PluginService.GetInfo:input_type -> plugins.Empty
-	16,  // 31: plugins.PluginService.OnEvent:input_type -> plugins.Event
-	18,  // 32: plugins.PluginService.OnHTTP:input_type -> plugins.HTTPRequest
-	20,  // 33: plugins.PluginService.OnSchedule:input_type -> plugins.ScheduleRequest
-	11,  // 34: plugins.PluginService.OnMixin:input_type -> plugins.MixinRequest
-	1,   // 35: plugins.PluginService.Shutdown:input_type -> plugins.Empty
-	2,   // 36: plugins.PanelService.GetServer:input_type -> plugins.IDRequest
-	22,  // 37: plugins.PanelService.ListServers:input_type -> plugins.ListServersRequest
-	24,  // 38: plugins.PanelService.CreateServer:input_type -> plugins.CreateServerRequest
-	2,   // 39: plugins.PanelService.DeleteServer:input_type -> plugins.IDRequest
-	25,  // 40: plugins.PanelService.UpdateServer:input_type -> plugins.UpdateServerRequest
-	2,   // 41: plugins.PanelService.SuspendServer:input_type -> plugins.IDRequest
-	2,   // 42: plugins.PanelService.UnsuspendServer:input_type -> plugins.IDRequest
-	2,   // 43: plugins.PanelService.StartServer:input_type -> plugins.IDRequest
-	2,   // 44: plugins.PanelService.StopServer:input_type -> plugins.IDRequest
-	2,   // 45: plugins.PanelService.RestartServer:input_type -> plugins.IDRequest
-	2,   // 46: plugins.PanelService.KillServer:input_type -> plugins.IDRequest
-	2,   // 47: plugins.PanelService.ReinstallServer:input_type -> plugins.IDRequest
-	26,  // 48: plugins.PanelService.TransferServer:input_type -> plugins.TransferServerRequest
-	27,  // 49: plugins.PanelService.GetConsoleLog:input_type -> plugins.ConsoleLogRequest
-	29,  // 50: plugins.PanelService.SendCommand:input_type -> plugins.SendCommandRequest
-	34,  // 51: plugins.PanelService.StreamConsole:input_type -> plugins.StreamConsoleRequest
-	2,   // 52: plugins.PanelService.GetFullLog:input_type -> plugins.IDRequest
-	37,  // 53: plugins.PanelService.SearchLogs:input_type -> plugins.SearchLogsRequest
-	2,   // 54: plugins.PanelService.ListLogFiles:input_type -> plugins.IDRequest
-	42,  // 55: plugins.PanelService.ReadLogFile:input_type -> plugins.ReadLogFileRequest
-	2,   // 56: plugins.PanelService.GetServerStats:input_type -> plugins.IDRequest
-	31,  // 57: plugins.PanelService.AddAllocation:input_type -> plugins.AllocationRequest
-	31,  // 58: plugins.PanelService.DeleteAllocation:input_type -> plugins.AllocationRequest
-	31,  // 59: plugins.PanelService.SetPrimaryAllocation:input_type -> plugins.AllocationRequest
-	33,  // 60: plugins.PanelService.UpdateServerVariables:input_type -> plugins.UpdateVariablesRequest
-	2,   // 61: plugins.PanelService.GetUser:input_type -> plugins.IDRequest
-	3,   // 62: plugins.PanelService.GetUserByEmail:input_type -> plugins.EmailRequest
-	4,   // 63: plugins.PanelService.GetUserByUsername:input_type -> plugins.UsernameRequest
-	44,  // 64: plugins.PanelService.ListUsers:input_type -> plugins.ListUsersRequest
-	46,  // 65: plugins.PanelService.CreateUser:input_type -> plugins.CreateUserRequest
-	2,   // 66: plugins.PanelService.DeleteUser:input_type -> plugins.IDRequest
-	47,  // 67: plugins.PanelService.UpdateUser:input_type -> plugins.UpdateUserRequest
-	2,   // 68: plugins.PanelService.BanUser:input_type -> plugins.IDRequest
-	2,   // 69: plugins.PanelService.UnbanUser:input_type -> plugins.IDRequest
-	2,   // 70: plugins.PanelService.SetAdmin:input_type -> plugins.IDRequest
-	2,   // 71: plugins.PanelService.RevokeAdmin:input_type -> plugins.IDRequest
-	48,  // 72: plugins.PanelService.SetUserResources:input_type -> plugins.SetUserResourcesRequest
-	2,   // 73: plugins.PanelService.ForcePasswordReset:input_type -> plugins.IDRequest
-	2,   // 74: plugins.PanelService.ListSubusers:input_type -> plugins.IDRequest
-	51,  // 75: plugins.PanelService.AddSubuser:input_type -> plugins.AddSubuserRequest
-	52,  // 76: plugins.PanelService.UpdateSubuser:input_type -> plugins.UpdateSubuserRequest
-	53,  // 77: plugins.PanelService.RemoveSubuser:input_type -> plugins.RemoveSubuserRequest
-	2,   // 78: plugins.PanelService.ListDatabases:input_type -> plugins.IDRequest
-	56,  // 79: plugins.PanelService.CreateDatabase:input_type -> plugins.CreateDatabaseRequest
-	2,   // 80: plugins.PanelService.DeleteDatabase:input_type -> plugins.IDRequest
-	2,   // 81: plugins.PanelService.RotateDatabasePassword:input_type -> plugins.IDRequest
-	1,   // 82: plugins.PanelService.ListDatabaseHosts:input_type -> plugins.Empty
-	59,  // 83: plugins.PanelService.CreateDatabaseHost:input_type -> plugins.CreateDatabaseHostRequest
-	60,  // 84: plugins.PanelService.UpdateDatabaseHost:input_type -> plugins.UpdateDatabaseHostRequest
-	2,   // 85: plugins.PanelService.DeleteDatabaseHost:input_type -> plugins.IDRequest
-	63,  // 86: plugins.PanelService.ListFiles:input_type -> plugins.FilePathRequest
-	63,  // 87: plugins.PanelService.ReadFile:input_type -> plugins.FilePathRequest
-	65,  // 88: plugins.PanelService.WriteFile:input_type -> plugins.WriteFileRequest
-	63,  // 89: plugins.PanelService.DeleteFile:input_type -> plugins.FilePathRequest
-	63,  // 90: plugins.PanelService.CreateFolder:input_type -> plugins.FilePathRequest
-	66,  // 91: plugins.PanelService.MoveFile:input_type -> plugins.MoveFileRequest
-	66,  // 92: plugins.PanelService.CopyFile:input_type -> plugins.MoveFileRequest
-	32,  // 93: plugins.PanelService.CompressFiles:input_type -> plugins.CompressRequest
-	63,  // 94: plugins.PanelService.DecompressFile:input_type -> plugins.FilePathRequest
-	2,   // 95: plugins.PanelService.ListBackups:input_type -> plugins.IDRequest
-	69,  // 96: plugins.PanelService.CreateBackup:input_type -> plugins.CreateBackupRequest
-	70,  // 97: plugins.PanelService.DeleteBackup:input_type -> plugins.DeleteBackupRequest
-	1,   // 98: plugins.PanelService.ListNodes:input_type -> plugins.Empty
-	2,   // 99: plugins.PanelService.GetNode:input_type -> plugins.IDRequest
-	73,  // 100: plugins.PanelService.CreateNode:input_type -> plugins.CreateNodeRequest
-	2,   // 101: plugins.PanelService.DeleteNode:input_type -> plugins.IDRequest
-	2,   // 102: plugins.PanelService.ResetNodeToken:input_type -> plugins.IDRequest
-	1,   // 103: plugins.PanelService.ListPackages:input_type -> plugins.Empty
-	2,   // 104: plugins.PanelService.GetPackage:input_type -> plugins.IDRequest
-	78,  // 105: plugins.PanelService.CreatePackage:input_type -> plugins.CreatePackageRequest
-	79,  // 106: plugins.PanelService.UpdatePackage:input_type -> plugins.UpdatePackageRequest
-	2,   // 107: plugins.PanelService.DeletePackage:input_type -> plugins.IDRequest
-	1,   // 108: plugins.PanelService.ListIPBans:input_type -> plugins.Empty
-	82,  // 109: plugins.PanelService.CreateIPBan:input_type -> plugins.CreateIPBanRequest
-	2,   // 110: plugins.PanelService.DeleteIPBan:input_type -> plugins.IDRequest
-	1,   // 111: plugins.PanelService.GetSettings:input_type -> plugins.Empty
-	5,   // 112: plugins.PanelService.SetRegistrationEnabled:input_type -> plugins.BoolRequest
-	5,   // 113: plugins.PanelService.SetServerCreationEnabled:input_type -> plugins.BoolRequest
-	85,  // 114: plugins.PanelService.GetActivityLogs:input_type -> plugins.GetLogsRequest
-	87,  // 115: plugins.PanelService.Log:input_type -> plugins.LogRequest
-	88,  // 116: plugins.PanelService.GetKV:input_type -> plugins.KVRequest
-	90,  // 117: plugins.PanelService.SetKV:input_type -> plugins.KVSetRequest
-	88,  // 118: plugins.PanelService.DeleteKV:input_type -> plugins.KVRequest
-	91,  // 119: plugins.PanelService.QueryDB:input_type -> plugins.QueryDBRequest
-	93,  // 120: plugins.PanelService.BroadcastEvent:input_type -> plugins.BroadcastEventRequest
-	94,  // 121: plugins.PanelService.SendNotification:input_type -> plugins.NotificationRequest
-	95,  // 122: plugins.PanelService.HTTPRequest:input_type -> plugins.PluginHTTPRequest
-	97,  // 123: plugins.PanelService.CallPlugin:input_type -> plugins.CallPluginRequest
-	6,   // 124: plugins.PluginService.GetInfo:output_type -> plugins.PluginInfo
-	17,  // 125: plugins.PluginService.OnEvent:output_type -> plugins.EventResponse
-	19,  // 126: plugins.PluginService.OnHTTP:output_type -> plugins.HTTPResponse
-	1,   // 127: plugins.PluginService.OnSchedule:output_type -> plugins.Empty
-	12,  // 128: plugins.PluginService.OnMixin:output_type -> plugins.MixinResponse
-	1,   // 129: plugins.PluginService.Shutdown:output_type -> plugins.Empty
-	21,  // 130: plugins.PanelService.GetServer:output_type -> plugins.Server
-	23,  // 131: plugins.PanelService.ListServers:output_type -> plugins.ListServersResponse
-	21,  // 132: plugins.PanelService.CreateServer:output_type -> plugins.Server
-	1,   // 133: plugins.PanelService.DeleteServer:output_type -> plugins.Empty
-	21,  // 134: plugins.PanelService.UpdateServer:output_type -> plugins.Server
-	1,   // 135: plugins.PanelService.SuspendServer:output_type -> plugins.Empty
-	1,   // 136: plugins.PanelService.UnsuspendServer:output_type -> plugins.Empty
-	1,   // 137: plugins.PanelService.StartServer:output_type -> plugins.Empty
-	1,   // 138: plugins.PanelService.StopServer:output_type -> plugins.Empty
-	1,   // 139: plugins.PanelService.RestartServer:output_type -> plugins.Empty
-	1,   // 140: plugins.PanelService.KillServer:output_type -> plugins.Empty
-	1,   // 141: plugins.PanelService.ReinstallServer:output_type -> plugins.Empty
-	1,   // 142: plugins.PanelService.TransferServer:output_type -> plugins.Empty
-	28,  // 143: plugins.PanelService.GetConsoleLog:output_type -> plugins.ConsoleLogResponse
-	1,   // 144: plugins.PanelService.SendCommand:output_type -> plugins.Empty
-	35,  // 145: plugins.PanelService.StreamConsole:output_type -> plugins.ConsoleLine
-	36,  // 146: plugins.PanelService.GetFullLog:output_type -> plugins.FullLogResponse
-	38,  // 147: plugins.PanelService.SearchLogs:output_type -> plugins.SearchLogsResponse
-	40,  // 148: plugins.PanelService.ListLogFiles:output_type -> plugins.LogFilesResponse
-	36,  // 149: plugins.PanelService.ReadLogFile:output_type -> plugins.FullLogResponse
-	30,  // 150: plugins.PanelService.GetServerStats:output_type -> plugins.ServerStats
-	1,   // 151: plugins.PanelService.AddAllocation:output_type -> plugins.Empty
-	1,   // 152: plugins.PanelService.DeleteAllocation:output_type -> plugins.Empty
-	1,   // 153: plugins.PanelService.SetPrimaryAllocation:output_type -> plugins.Empty
-	1,   // 154: plugins.PanelService.UpdateServerVariables:output_type -> plugins.Empty
-	43,  // 155: plugins.PanelService.GetUser:output_type -> plugins.User
-	43,  // 156: plugins.PanelService.GetUserByEmail:output_type -> plugins.User
-	43,  // 157: plugins.PanelService.GetUserByUsername:output_type -> plugins.User
-	45,  // 158: plugins.PanelService.ListUsers:output_type -> plugins.ListUsersResponse
-	43,  // 159: plugins.PanelService.CreateUser:output_type -> plugins.User
-	1,   // 160: plugins.PanelService.DeleteUser:output_type -> plugins.Empty
-	43,  // 161: plugins.PanelService.UpdateUser:output_type -> plugins.User
-	1,   // 162: plugins.PanelService.BanUser:output_type -> plugins.Empty
-	1,   // 163: plugins.PanelService.UnbanUser:output_type -> plugins.Empty
-	1,   // 164: plugins.PanelService.SetAdmin:output_type -> plugins.Empty
-	1,   // 165: plugins.PanelService.RevokeAdmin:output_type -> plugins.Empty
-	1,   // 166: plugins.PanelService.SetUserResources:output_type -> plugins.Empty
-	1,   // 167: plugins.PanelService.ForcePasswordReset:output_type -> plugins.Empty
-	50,  // 168: plugins.PanelService.ListSubusers:output_type -> plugins.ListSubusersResponse
-	49,  // 169: plugins.PanelService.AddSubuser:output_type -> plugins.Subuser
-	1,   // 170: plugins.PanelService.UpdateSubuser:output_type -> plugins.Empty
-	1,   // 171: plugins.PanelService.RemoveSubuser:output_type -> plugins.Empty
-	55,  // 172: plugins.PanelService.ListDatabases:output_type -> plugins.ListDatabasesResponse
-	54,  // 173: plugins.PanelService.CreateDatabase:output_type -> plugins.Database
-	1,   // 174: plugins.PanelService.DeleteDatabase:output_type -> plugins.Empty
-	54,  // 175: plugins.PanelService.RotateDatabasePassword:output_type -> plugins.Database
-	58,  // 176: plugins.PanelService.ListDatabaseHosts:output_type -> plugins.ListDatabaseHostsResponse
-	57,  // 177: plugins.PanelService.CreateDatabaseHost:output_type -> plugins.DatabaseHost
-	1,   // 178: plugins.PanelService.UpdateDatabaseHost:output_type -> plugins.Empty
-	1,   // 179: plugins.PanelService.DeleteDatabaseHost:output_type -> plugins.Empty
-	62,  // 180: plugins.PanelService.ListFiles:output_type -> plugins.ListFilesResponse
-	64,  // 181: plugins.PanelService.ReadFile:output_type -> plugins.FileContent
-	1,   // 182: plugins.PanelService.WriteFile:output_type -> plugins.Empty
-	1,   // 183: plugins.PanelService.DeleteFile:output_type -> plugins.Empty
-	1,   // 184: plugins.PanelService.CreateFolder:output_type -> plugins.Empty
-	1,   // 185: plugins.PanelService.MoveFile:output_type -> plugins.Empty
-	1,   // 186: plugins.PanelService.CopyFile:output_type -> plugins.Empty
-	1,   // 187: plugins.PanelService.CompressFiles:output_type -> plugins.Empty
-	1,   // 188: plugins.PanelService.DecompressFile:output_type -> plugins.Empty
-	68,  // 189: plugins.PanelService.ListBackups:output_type -> plugins.ListBackupsResponse
-	1,   // 190: plugins.PanelService.CreateBackup:output_type -> plugins.Empty
-	1,   // 191: plugins.PanelService.DeleteBackup:output_type -> plugins.Empty
-	72,  // 192: plugins.PanelService.ListNodes:output_type -> plugins.ListNodesResponse
-	71,  // 193: plugins.PanelService.GetNode:output_type -> plugins.Node
-	74,  // 194: plugins.PanelService.CreateNode:output_type -> plugins.NodeWithToken
-	1,   // 195: plugins.PanelService.DeleteNode:output_type -> plugins.Empty
-	75,  // 196: plugins.PanelService.ResetNodeToken:output_type -> plugins.NodeToken
-	77,  // 197: plugins.PanelService.ListPackages:output_type -> plugins.ListPackagesResponse
-	76,  // 198: plugins.PanelService.GetPackage:output_type -> plugins.Package
-	76,  // 199: plugins.PanelService.CreatePackage:output_type -> plugins.Package
-	76,  // 200: plugins.PanelService.UpdatePackage:output_type -> plugins.Package
-	1,   // 201: plugins.PanelService.DeletePackage:output_type -> plugins.Empty
-	81,  // 202: plugins.PanelService.ListIPBans:output_type -> plugins.ListIPBansResponse
-	80,  // 203: plugins.PanelService.CreateIPBan:output_type -> plugins.IPBan
-	1,   // 204: plugins.PanelService.DeleteIPBan:output_type -> plugins.Empty
-	83,  // 205: plugins.PanelService.GetSettings:output_type -> plugins.Settings
-	1,   // 206: plugins.PanelService.SetRegistrationEnabled:output_type -> plugins.Empty
-	1,   // 207: plugins.PanelService.SetServerCreationEnabled:output_type -> plugins.Empty
-	86,  // 208: plugins.PanelService.GetActivityLogs:output_type -> plugins.GetLogsResponse
-	1,   // 209: plugins.PanelService.Log:output_type -> plugins.Empty
-	89,  // 210: plugins.PanelService.GetKV:output_type -> plugins.KVResponse
-	1,   // 211: plugins.PanelService.SetKV:output_type -> plugins.Empty
-	1,   // 212: plugins.PanelService.DeleteKV:output_type -> plugins.Empty
-	92,  // 213: plugins.PanelService.QueryDB:output_type -> plugins.QueryDBResponse
-	1,   // 214: plugins.PanelService.BroadcastEvent:output_type -> plugins.Empty
-	1,   // 215: plugins.PanelService.SendNotification:output_type -> plugins.Empty
-	96,  // 216: plugins.PanelService.HTTPRequest:output_type -> plugins.PluginHTTPResponse
-	98,  // 217: plugins.PanelService.CallPlugin:output_type -> plugins.CallPluginResponse
-	124, // [124:218] is the sub-list for method output_type
-	30,  // [30:124] is the sub-list for method input_type
-	30,  // [30:30] is the sub-list for extension type_name
-	30,  // [30:30] is the sub-list for extension extendee
-	0,   // [0:30] is the sub-list for field type_name
+	72,  // 21: plugins.ListBackupsResponse.backups:type_name -> plugins.Backup
+	113, // 22: plugins.Node.labels:type_name -> plugins.Node.LabelsEntry
+	79,  // 23: plugins.ListNodesResponse.nodes:type_name -> plugins.Node
+	79,  // 24: plugins.NodeWithToken.node:type_name -> plugins.Node
+	84,  // 25: plugins.ListPackagesResponse.packages:type_name -> plugins.Package
+	88,  // 26: plugins.ListIPBansResponse.bans:type_name -> plugins.IPBan
+	92,  // 27: plugins.GetLogsResponse.logs:type_name -> plugins.ActivityLog
+	99,  // 28: plugins.QueryDBRequest.typed_args:type_name -> plugins.QueryArg
+	114, // 29: plugins.BroadcastEventRequest.data:type_name -> plugins.BroadcastEventRequest.DataEntry
+	115, // 30: plugins.PluginHTTPRequest.headers:type_name -> plugins.PluginHTTPRequest.HeadersEntry
+	116, // 31: plugins.PluginHTTPResponse.headers:type_name -> plugins.PluginHTTPResponse.HeadersEntry
+	1,   // 32: plugins.PluginService.GetInfo:input_type -> plugins.Empty
+	16,  // 33: plugins.PluginService.OnEvent:input_type -> plugins.Event
+	18,  // 34: plugins.PluginService.OnHTTP:input_type -> plugins.HTTPRequest
+	20,  // 35: plugins.PluginService.OnSchedule:input_type -> plugins.ScheduleRequest
+	11,  // 36: plugins.PluginService.OnMixin:input_type -> plugins.MixinRequest
+	1,   // 37: plugins.PluginService.Shutdown:input_type -> plugins.Empty
+	2,   // 38: plugins.PanelService.GetServer:input_type -> plugins.IDRequest
+	22,  // 39: plugins.PanelService.ListServers:input_type -> plugins.ListServersRequest
+	24,  // 40: plugins.PanelService.CreateServer:input_type -> plugins.CreateServerRequest
+	2,   // 41: plugins.PanelService.DeleteServer:input_type -> plugins.IDRequest
+	25,  // 42: plugins.PanelService.UpdateServer:input_type -> plugins.UpdateServerRequest
+	2,   // 43: plugins.PanelService.SuspendServer:input_type -> plugins.IDRequest
+	2,   // 44: plugins.PanelService.UnsuspendServer:input_type -> plugins.IDRequest
+	2,   // 45: plugins.PanelService.StartServer:input_type -> plugins.IDRequest
+	2,   // 46: plugins.PanelService.StopServer:input_type -> plugins.IDRequest
+	2,   // 47: plugins.PanelService.RestartServer:input_type -> plugins.IDRequest
+	2,   // 48: plugins.PanelService.KillServer:input_type -> plugins.IDRequest
+	2,   // 49: plugins.PanelService.ReinstallServer:input_type -> plugins.IDRequest
+	26,  // 50: plugins.PanelService.TransferServer:input_type -> plugins.TransferServerRequest
+	27,  // 51: plugins.PanelService.GetConsoleLog:input_type -> plugins.ConsoleLogRequest
+	29,  // 52: plugins.PanelService.SendCommand:input_type -> plugins.SendCommandRequest
+	34,  // 53: plugins.PanelService.StreamConsole:input_type -> plugins.StreamConsoleRequest
+	2,   // 54: plugins.PanelService.GetFullLog:input_type -> plugins.IDRequest
+	37,  // 55: plugins.PanelService.SearchLogs:input_type -> plugins.SearchLogsRequest
+	2,   // 56: plugins.PanelService.ListLogFiles:input_type -> plugins.IDRequest
+	42,  // 57: plugins.PanelService.ReadLogFile:input_type -> plugins.ReadLogFileRequest
+	2,   // 58: plugins.PanelService.GetServerStats:input_type -> plugins.IDRequest
+	31,  // 59: plugins.PanelService.AddAllocation:input_type -> plugins.AllocationRequest
+	31,  // 60: plugins.PanelService.DeleteAllocation:input_type -> plugins.AllocationRequest
+	31,  // 61: plugins.PanelService.SetPrimaryAllocation:input_type -> plugins.AllocationRequest
+	33,  // 62: plugins.PanelService.UpdateServerVariables:input_type -> plugins.UpdateVariablesRequest
+	2,   // 63: plugins.PanelService.GetUser:input_type -> plugins.IDRequest
+	3,   // 64: plugins.PanelService.GetUserByEmail:input_type -> plugins.EmailRequest
+	4,   // 65: plugins.PanelService.GetUserByUsername:input_type -> plugins.UsernameRequest
+	44,  // 66: plugins.PanelService.ListUsers:input_type -> plugins.ListUsersRequest
+	46,  // 67: plugins.PanelService.CreateUser:input_type -> plugins.CreateUserRequest
+	2,   // 68: plugins.PanelService.DeleteUser:input_type -> plugins.IDRequest
+	47,  // 69: plugins.PanelService.UpdateUser:input_type -> plugins.UpdateUserRequest
+	2,   // 70: plugins.PanelService.BanUser:input_type -> plugins.IDRequest
+	2,   // 71: plugins.PanelService.UnbanUser:input_type -> plugins.IDRequest
+	2,   // 72: plugins.PanelService.SetAdmin:input_type -> plugins.IDRequest
+	2,   // 73: plugins.PanelService.RevokeAdmin:input_type -> plugins.IDRequest
+	48,  // 74: plugins.PanelService.SetUserResources:input_type -> plugins.SetUserResourcesRequest
+	2,   // 75: plugins.PanelService.ForcePasswordReset:input_type -> plugins.IDRequest
+	2,   // 76: plugins.PanelService.ListSubusers:input_type -> plugins.IDRequest
+	51,  // 77: plugins.PanelService.AddSubuser:input_type -> plugins.AddSubuserRequest
+	52,  // 78: plugins.PanelService.UpdateSubuser:input_type -> plugins.UpdateSubuserRequest
+	53,  // 79: plugins.PanelService.RemoveSubuser:input_type -> plugins.RemoveSubuserRequest
+	2,   // 80: plugins.PanelService.ListDatabases:input_type -> plugins.IDRequest
+	56,  // 81: plugins.PanelService.CreateDatabase:input_type -> plugins.CreateDatabaseRequest
+	2,   // 82: plugins.PanelService.DeleteDatabase:input_type -> plugins.IDRequest
+	2,   // 83: plugins.PanelService.RotateDatabasePassword:input_type -> plugins.IDRequest
+	1,   // 84: plugins.PanelService.ListDatabaseHosts:input_type -> plugins.Empty
+	59,  // 85: plugins.PanelService.CreateDatabaseHost:input_type -> plugins.CreateDatabaseHostRequest
+	60,  // 86: plugins.PanelService.UpdateDatabaseHost:input_type -> plugins.UpdateDatabaseHostRequest
+	2,   // 87: plugins.PanelService.DeleteDatabaseHost:input_type -> plugins.IDRequest
+	63,  // 88: plugins.PanelService.ListFiles:input_type -> plugins.FilePathRequest
+	63,  // 89: plugins.PanelService.ReadFile:input_type -> plugins.FilePathRequest
+	65,  // 90: plugins.PanelService.WriteFile:input_type -> plugins.WriteFileRequest
+	63,  // 91: plugins.PanelService.DeleteFile:input_type -> plugins.FilePathRequest
+	63,  // 92: plugins.PanelService.CreateFolder:input_type -> plugins.FilePathRequest
+	66,  // 93: plugins.PanelService.MoveFile:input_type -> plugins.MoveFileRequest
+	66,  // 94: plugins.PanelService.CopyFile:input_type -> plugins.MoveFileRequest
+	32,  // 95: plugins.PanelService.CompressFiles:input_type -> plugins.CompressRequest
+	63,  // 96: plugins.PanelService.DecompressFile:input_type -> plugins.FilePathRequest
+	67,  // 97: plugins.PanelService.DownloadFile:input_type -> plugins.DownloadFileRequest
+	68,  // 98: plugins.PanelService.UploadFile:input_type -> plugins.FileChunk
+	70,  // 99: plugins.PanelService.GetUploadStatus:input_type -> plugins.UploadStatusRequest
+	2,   // 100: plugins.PanelService.ListBackups:input_type -> plugins.IDRequest
+	74,  // 101: plugins.PanelService.CreateBackup:input_type -> plugins.CreateBackupRequest
+	75,  // 102: plugins.PanelService.DeleteBackup:input_type -> plugins.DeleteBackupRequest
+	76,  // 103: plugins.PanelService.GetBackup:input_type -> plugins.BackupRequest
+	77,  // 104: plugins.PanelService.RestoreBackup:input_type -> plugins.RestoreBackupRequest
+	78,  // 105: plugins.PanelService.DownloadBackup:input_type -> plugins.DownloadBackupRequest
+	1,   // 106: plugins.PanelService.ListNodes:input_type -> plugins.Empty
+	2,   // 107: plugins.PanelService.GetNode:input_type -> plugins.IDRequest
+	81,  // 108: plugins.PanelService.CreateNode:input_type -> plugins.CreateNodeRequest
+	2,   // 109: plugins.PanelService.DeleteNode:input_type -> plugins.IDRequest
+	2,   // 110: plugins.PanelService.ResetNodeToken:input_type -> plugins.IDRequest
+	1,   // 111: plugins.PanelService.ListPackages:input_type -> plugins.Empty
+	2,   // 112: plugins.PanelService.GetPackage:input_type -> plugins.IDRequest
+	86,  // 113: plugins.PanelService.CreatePackage:input_type -> plugins.CreatePackageRequest
+	87,  // 114: plugins.PanelService.UpdatePackage:input_type -> plugins.UpdatePackageRequest
+	2,   // 115: plugins.PanelService.DeletePackage:input_type -> plugins.IDRequest
+	1,   // 116: plugins.PanelService.ListIPBans:input_type -> plugins.Empty
+	90,  // 117: plugins.PanelService.CreateIPBan:input_type -> plugins.CreateIPBanRequest
+	2,   // 118: plugins.PanelService.DeleteIPBan:input_type -> plugins.IDRequest
+	1,   // 119: plugins.PanelService.GetSettings:input_type -> plugins.Empty
+	5,   // 120: plugins.PanelService.SetRegistrationEnabled:input_type -> plugins.BoolRequest
+	5,   // 121: plugins.PanelService.SetServerCreationEnabled:input_type -> plugins.BoolRequest
+	93,  // 122: plugins.PanelService.GetActivityLogs:input_type -> plugins.GetLogsRequest
+	95,  // 123: plugins.PanelService.Log:input_type -> plugins.LogRequest
+	96,  // 124: plugins.PanelService.GetKV:input_type -> plugins.KVRequest
+	98,  // 125: plugins.PanelService.SetKV:input_type -> plugins.KVSetRequest
+	96,  // 126: plugins.PanelService.DeleteKV:input_type -> plugins.KVRequest
+	100, // 127: plugins.PanelService.QueryDB:input_type -> plugins.QueryDBRequest
+	102, // 128: plugins.PanelService.BroadcastEvent:input_type -> plugins.BroadcastEventRequest
+	103, // 129: plugins.PanelService.SendNotification:input_type -> plugins.NotificationRequest
+	104, // 130: plugins.PanelService.HTTPRequest:input_type -> plugins.PluginHTTPRequest
+	106, // 131: plugins.PanelService.CallPlugin:input_type -> plugins.CallPluginRequest
+	6,   // 132: plugins.PluginService.GetInfo:output_type -> plugins.PluginInfo
+	17,  // 133: plugins.PluginService.OnEvent:output_type -> plugins.EventResponse
+	19,  // 134: plugins.PluginService.OnHTTP:output_type -> plugins.HTTPResponse
+	1,   // 135: plugins.PluginService.OnSchedule:output_type -> plugins.Empty
+	12,  // 136: plugins.PluginService.OnMixin:output_type -> plugins.MixinResponse
+	1,   // 137: plugins.PluginService.Shutdown:output_type -> plugins.Empty
+	21,  // 138: plugins.PanelService.GetServer:output_type -> plugins.Server
+	23,  // 139: plugins.PanelService.ListServers:output_type -> plugins.ListServersResponse
+	21,  // 140: plugins.PanelService.CreateServer:output_type -> plugins.Server
+	1,   // 141: plugins.PanelService.DeleteServer:output_type -> plugins.Empty
+	21,  // 142: plugins.PanelService.UpdateServer:output_type -> plugins.Server
+	1,   // 143: plugins.PanelService.SuspendServer:output_type -> plugins.Empty
+	1,   // 144: plugins.PanelService.UnsuspendServer:output_type -> plugins.Empty
+	1,   // 145: plugins.PanelService.StartServer:output_type -> plugins.Empty
+	1,   // 146: plugins.PanelService.StopServer:output_type -> plugins.Empty
+	1,   // 147: plugins.PanelService.RestartServer:output_type -> plugins.Empty
+	1,   // 148: plugins.PanelService.KillServer:output_type -> plugins.Empty
+	1,   // 149: plugins.PanelService.ReinstallServer:output_type -> plugins.Empty
+	1,   // 150: plugins.PanelService.TransferServer:output_type -> plugins.Empty
+	28,  // 151: plugins.PanelService.GetConsoleLog:output_type -> plugins.ConsoleLogResponse
+	1,   // 152: plugins.PanelService.SendCommand:output_type -> plugins.Empty
+	35,  // 153: plugins.PanelService.StreamConsole:output_type -> plugins.ConsoleLine
+	36,  // 154: plugins.PanelService.GetFullLog:output_type -> plugins.FullLogResponse
+	38,  // 155: plugins.PanelService.SearchLogs:output_type -> plugins.SearchLogsResponse
+	40,  // 156: plugins.PanelService.ListLogFiles:output_type -> plugins.LogFilesResponse
+	36,  // 157: plugins.PanelService.ReadLogFile:output_type -> plugins.FullLogResponse
+	30,  // 158: plugins.PanelService.GetServerStats:output_type -> plugins.ServerStats
+	1,   // 159: plugins.PanelService.AddAllocation:output_type -> plugins.Empty
+	1,   // 160: plugins.PanelService.DeleteAllocation:output_type -> plugins.Empty
+	1,   // 161: plugins.PanelService.SetPrimaryAllocation:output_type -> plugins.Empty
+	1,   // 162: plugins.PanelService.UpdateServerVariables:output_type -> plugins.Empty
+	43,  // 163: plugins.PanelService.GetUser:output_type -> plugins.User
+	43,  // 164: plugins.PanelService.GetUserByEmail:output_type -> plugins.User
+	43,  // 165: plugins.PanelService.GetUserByUsername:output_type -> plugins.User
+	45,  // 166: plugins.PanelService.ListUsers:output_type -> plugins.ListUsersResponse
+	43,  // 167: plugins.PanelService.CreateUser:output_type -> plugins.User
+	1,   // 168: plugins.PanelService.DeleteUser:output_type -> plugins.Empty
+	43,  // 169: plugins.PanelService.UpdateUser:output_type -> plugins.User
+	1,   // 170: plugins.PanelService.BanUser:output_type -> plugins.Empty
+	1,   // 171: plugins.PanelService.UnbanUser:output_type -> plugins.Empty
+	1,   // 172: plugins.PanelService.SetAdmin:output_type -> plugins.Empty
+	1,   // 173: plugins.PanelService.RevokeAdmin:output_type -> plugins.Empty
+	1,   // 174: plugins.PanelService.SetUserResources:output_type -> plugins.Empty
+	1,   // 175: plugins.PanelService.ForcePasswordReset:output_type -> plugins.Empty
+	50,  // 176: plugins.PanelService.ListSubusers:output_type -> plugins.ListSubusersResponse
+	49,  // 177: plugins.PanelService.AddSubuser:output_type -> plugins.Subuser
+	1,   // 178: plugins.PanelService.UpdateSubuser:output_type -> plugins.Empty
+	1,   // 179: plugins.PanelService.RemoveSubuser:output_type -> plugins.Empty
+	55,  // 180: plugins.PanelService.ListDatabases:output_type -> plugins.ListDatabasesResponse
+	54,  // 181: plugins.PanelService.CreateDatabase:output_type -> plugins.Database
+	1,   // 182: plugins.PanelService.DeleteDatabase:output_type -> plugins.Empty
+	54,  // 183: plugins.PanelService.RotateDatabasePassword:output_type -> plugins.Database
+	58,  // 184: plugins.PanelService.ListDatabaseHosts:output_type -> plugins.ListDatabaseHostsResponse
+	57,  // 185: plugins.PanelService.CreateDatabaseHost:output_type -> plugins.DatabaseHost
+	1,   // 186: plugins.PanelService.UpdateDatabaseHost:output_type -> plugins.Empty
+	1,   // 187: plugins.PanelService.DeleteDatabaseHost:output_type -> plugins.Empty
+	62,  // 188: plugins.PanelService.ListFiles:output_type -> plugins.ListFilesResponse
+	64,  // 189: plugins.PanelService.ReadFile:output_type -> plugins.FileContent
+	1,   // 190: plugins.PanelService.WriteFile:output_type -> plugins.Empty
+	1,   // 191: plugins.PanelService.DeleteFile:output_type -> plugins.Empty
+	1,   // 192: plugins.PanelService.CreateFolder:output_type -> plugins.Empty
+	1,   // 193: plugins.PanelService.MoveFile:output_type -> plugins.Empty
+	1,   // 194: plugins.PanelService.CopyFile:output_type -> plugins.Empty
+	1,   // 195: plugins.PanelService.CompressFiles:output_type -> plugins.Empty
+	1,   // 196: plugins.PanelService.DecompressFile:output_type -> plugins.Empty
+	68,  // 197: plugins.PanelService.DownloadFile:output_type -> plugins.FileChunk
+	69,  // 198: plugins.PanelService.UploadFile:output_type -> plugins.UploadFileResponse
+	71,  // 199: plugins.PanelService.GetUploadStatus:output_type -> plugins.UploadStatus
+	73,  // 200: plugins.PanelService.ListBackups:output_type -> plugins.ListBackupsResponse
+	1,   // 201: plugins.PanelService.CreateBackup:output_type -> plugins.Empty
+	1,   // 202: plugins.PanelService.DeleteBackup:output_type -> plugins.Empty
+	72,  // 203: plugins.PanelService.GetBackup:output_type -> plugins.Backup
+	1,   // 204: plugins.PanelService.RestoreBackup:output_type -> plugins.Empty
+	68,  // 205: plugins.PanelService.DownloadBackup:output_type -> plugins.FileChunk
+	80,  // 206: plugins.PanelService.ListNodes:output_type -> plugins.ListNodesResponse
+	79,  // 207: plugins.PanelService.GetNode:output_type -> plugins.Node
+	82,  // 208: plugins.PanelService.CreateNode:output_type -> plugins.NodeWithToken
+	1,   // 209: plugins.PanelService.DeleteNode:output_type -> plugins.Empty
+	83,  // 210: plugins.PanelService.ResetNodeToken:output_type -> plugins.NodeToken
+	85,  // 211: plugins.PanelService.ListPackages:output_type -> plugins.ListPackagesResponse
+	84,  // 212: plugins.PanelService.GetPackage:output_type -> plugins.Package
+	84,  // 213: plugins.PanelService.CreatePackage:output_type -> plugins.Package
+	84,  // 214: plugins.PanelService.UpdatePackage:output_type -> plugins.Package
+	1,   // 215: plugins.PanelService.DeletePackage:output_type -> plugins.Empty
+	89,  // 216: plugins.PanelService.ListIPBans:output_type -> plugins.ListIPBansResponse
+	88,  // 217: plugins.PanelService.CreateIPBan:output_type -> plugins.IPBan
+	1,   // 218: plugins.PanelService.DeleteIPBan:output_type -> plugins.Empty
+	91,  // 219: plugins.PanelService.GetSettings:output_type -> plugins.Settings
+	1,   // 220: plugins.PanelService.SetRegistrationEnabled:output_type -> plugins.Empty
+	1,   // 221: plugins.PanelService.SetServerCreationEnabled:output_type -> plugins.Empty
+	94,  // 222: plugins.PanelService.GetActivityLogs:output_type -> plugins.GetLogsResponse
+	1,   // 223: plugins.PanelService.Log:output_type -> plugins.Empty
+	97,  // 224: plugins.PanelService.GetKV:output_type -> plugins.KVResponse
+	1,   // 225: plugins.PanelService.SetKV:output_type -> plugins.Empty
+	1,   // 226: plugins.PanelService.DeleteKV:output_type -> plugins.Empty
+	101, // 227: plugins.PanelService.QueryDB:output_type -> plugins.QueryDBResponse
+	1,   // 228: plugins.PanelService.BroadcastEvent:output_type -> plugins.Empty
+	1,   // 229: plugins.PanelService.SendNotification:output_type -> plugins.Empty
+	105, // 230: plugins.PanelService.HTTPRequest:output_type -> plugins.PluginHTTPResponse
+	107, // 231: plugins.PanelService.CallPlugin:output_type -> plugins.CallPluginResponse
+	132, // [132:232] is the sub-list for method output_type
+	32,  // [32:132] is the sub-list for method input_type
+	32,  // [32:32] is the sub-list for extension type_name
+	32,  // [32:32] is the sub-list for extension extendee
+	0,   // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_plugin_proto_init() }
@@ -7187,13 +8113,22 @@ func file_plugin_proto_init() {
 	if File_plugin_proto != nil {
 		return
 	}
+	file_plugin_proto_msgTypes[98].OneofWrappers = []any{
+		(*QueryArg_StringValue)(nil),
+		(*QueryArg_IntValue)(nil),
+		(*QueryArg_FloatValue)(nil),
+		(*QueryArg_BoolValue)(nil),
+		(*QueryArg_TimeValue)(nil),
+		(*QueryArg_BytesValue)(nil),
+		(*QueryArg_NullValue)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_plugin_proto_rawDesc), len(file_plugin_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   106,
+			NumMessages:   116,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
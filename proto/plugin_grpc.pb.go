@@ -370,9 +370,15 @@ const (
 	PanelService_CopyFile_FullMethodName                 = "/plugins.PanelService/CopyFile"
 	PanelService_CompressFiles_FullMethodName            = "/plugins.PanelService/CompressFiles"
 	PanelService_DecompressFile_FullMethodName           = "/plugins.PanelService/DecompressFile"
+	PanelService_DownloadFile_FullMethodName             = "/plugins.PanelService/DownloadFile"
+	PanelService_UploadFile_FullMethodName               = "/plugins.PanelService/UploadFile"
+	PanelService_GetUploadStatus_FullMethodName          = "/plugins.PanelService/GetUploadStatus"
 	PanelService_ListBackups_FullMethodName              = "/plugins.PanelService/ListBackups"
 	PanelService_CreateBackup_FullMethodName             = "/plugins.PanelService/CreateBackup"
 	PanelService_DeleteBackup_FullMethodName             = "/plugins.PanelService/DeleteBackup"
+	PanelService_GetBackup_FullMethodName                = "/plugins.PanelService/GetBackup"
+	PanelService_RestoreBackup_FullMethodName            = "/plugins.PanelService/RestoreBackup"
+	PanelService_DownloadBackup_FullMethodName           = "/plugins.PanelService/DownloadBackup"
 	PanelService_ListNodes_FullMethodName                = "/plugins.PanelService/ListNodes"
 	PanelService_GetNode_FullMethodName                  = "/plugins.PanelService/GetNode"
 	PanelService_CreateNode_FullMethodName               = "/plugins.PanelService/CreateNode"
@@ -474,10 +480,16 @@ type PanelServiceClient interface {
 	CopyFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*Empty, error)
 	CompressFiles(ctx context.Context, in *CompressRequest, opts ...grpc.CallOption) (*Empty, error)
 	DecompressFile(ctx context.Context, in *FilePathRequest, opts ...grpc.CallOption) (*Empty, error)
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, UploadFileResponse], error)
+	GetUploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...grpc.CallOption) (*UploadStatus, error)
 	// Backups
 	ListBackups(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
 	CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Backup, error)
+	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*Empty, error)
+	DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
 	// Nodes
 	ListNodes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNodesResponse, error)
 	GetNode(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Node, error)
@@ -1121,6 +1133,48 @@ func (c *panelServiceClient) DecompressFile(ctx context.Context, in *FilePathReq
 	return out, nil
 }
 
+func (c *panelServiceClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PanelService_ServiceDesc.Streams[1], PanelService_DownloadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadFileRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_DownloadFileClient = grpc.ServerStreamingClient[FileChunk]
+
+func (c *panelServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, UploadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PanelService_ServiceDesc.Streams[2], PanelService_UploadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileChunk, UploadFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_UploadFileClient = grpc.ClientStreamingClient[FileChunk, UploadFileResponse]
+
+func (c *panelServiceClient) GetUploadStatus(ctx context.Context, in *UploadStatusRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, PanelService_GetUploadStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *panelServiceClient) ListBackups(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListBackupsResponse)
@@ -1151,6 +1205,45 @@ func (c *panelServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupR
 	return out, nil
 }
 
+func (c *panelServiceClient) GetBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Backup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Backup)
+	err := c.cc.Invoke(ctx, PanelService_GetBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *panelServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PanelService_RestoreBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *panelServiceClient) DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PanelService_ServiceDesc.Streams[3], PanelService_DownloadBackup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadBackupRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_DownloadBackupClient = grpc.ServerStreamingClient[FileChunk]
+
 func (c *panelServiceClient) ListNodes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNodesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNodesResponse)
@@ -1484,10 +1577,16 @@ type PanelServiceServer interface {
 	CopyFile(context.Context, *MoveFileRequest) (*Empty, error)
 	CompressFiles(context.Context, *CompressRequest) (*Empty, error)
 	DecompressFile(context.Context, *FilePathRequest) (*Empty, error)
+	DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[FileChunk]) error
+	UploadFile(grpc.ClientStreamingServer[FileChunk, UploadFileResponse]) error
+	GetUploadStatus(context.Context, *UploadStatusRequest) (*UploadStatus, error)
 	// Backups
 	ListBackups(context.Context, *IDRequest) (*ListBackupsResponse, error)
 	CreateBackup(context.Context, *CreateBackupRequest) (*Empty, error)
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*Empty, error)
+	GetBackup(context.Context, *BackupRequest) (*Backup, error)
+	RestoreBackup(context.Context, *RestoreBackupRequest) (*Empty, error)
+	DownloadBackup(*DownloadBackupRequest, grpc.ServerStreamingServer[FileChunk]) error
 	// Nodes
 	ListNodes(context.Context, *Empty) (*ListNodesResponse, error)
 	GetNode(context.Context, *IDRequest) (*Node, error)
@@ -1709,6 +1808,15 @@ func (UnimplementedPanelServiceServer) CompressFiles(context.Context, *CompressR
 func (UnimplementedPanelServiceServer) DecompressFile(context.Context, *FilePathRequest) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DecompressFile not implemented")
 }
+func (UnimplementedPanelServiceServer) DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadFile not implemented")
+}
+func (UnimplementedPanelServiceServer) UploadFile(grpc.ClientStreamingServer[FileChunk, UploadFileResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedPanelServiceServer) GetUploadStatus(context.Context, *UploadStatusRequest) (*UploadStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUploadStatus not implemented")
+}
 func (UnimplementedPanelServiceServer) ListBackups(context.Context, *IDRequest) (*ListBackupsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListBackups not implemented")
 }
@@ -1718,6 +1826,15 @@ func (UnimplementedPanelServiceServer) CreateBackup(context.Context, *CreateBack
 func (UnimplementedPanelServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteBackup not implemented")
 }
+func (UnimplementedPanelServiceServer) GetBackup(context.Context, *BackupRequest) (*Backup, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackup not implemented")
+}
+func (UnimplementedPanelServiceServer) RestoreBackup(context.Context, *RestoreBackupRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreBackup not implemented")
+}
+func (UnimplementedPanelServiceServer) DownloadBackup(*DownloadBackupRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadBackup not implemented")
+}
 func (UnimplementedPanelServiceServer) ListNodes(context.Context, *Empty) (*ListNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNodes not implemented")
 }
@@ -2872,6 +2989,42 @@ func _PanelService_DecompressFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PanelService_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PanelServiceServer).DownloadFile(m, &grpc.GenericServerStream[DownloadFileRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_DownloadFileServer = grpc.ServerStreamingServer[FileChunk]
+
+func _PanelService_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PanelServiceServer).UploadFile(&grpc.GenericServerStream[FileChunk, UploadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_UploadFileServer = grpc.ClientStreamingServer[FileChunk, UploadFileResponse]
+
+func _PanelService_GetUploadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PanelServiceServer).GetUploadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PanelService_GetUploadStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PanelServiceServer).GetUploadStatus(ctx, req.(*UploadStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PanelService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IDRequest)
 	if err := dec(in); err != nil {
@@ -2926,6 +3079,53 @@ func _PanelService_DeleteBackup_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PanelService_GetBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PanelServiceServer).GetBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PanelService_GetBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PanelServiceServer).GetBackup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PanelService_RestoreBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PanelServiceServer).RestoreBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PanelService_RestoreBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PanelServiceServer).RestoreBackup(ctx, req.(*RestoreBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PanelService_DownloadBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PanelServiceServer).DownloadBackup(m, &grpc.GenericServerStream[DownloadBackupRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PanelService_DownloadBackupServer = grpc.ServerStreamingServer[FileChunk]
+
 func _PanelService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -3633,6 +3833,10 @@ var PanelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DecompressFile",
 			Handler:    _PanelService_DecompressFile_Handler,
 		},
+		{
+			MethodName: "GetUploadStatus",
+			Handler:    _PanelService_GetUploadStatus_Handler,
+		},
 		{
 			MethodName: "ListBackups",
 			Handler:    _PanelService_ListBackups_Handler,
@@ -3645,6 +3849,14 @@ var PanelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBackup",
 			Handler:    _PanelService_DeleteBackup_Handler,
 		},
+		{
+			MethodName: "GetBackup",
+			Handler:    _PanelService_GetBackup_Handler,
+		},
+		{
+			MethodName: "RestoreBackup",
+			Handler:    _PanelService_RestoreBackup_Handler,
+		},
 		{
 			MethodName: "ListNodes",
 			Handler:    _PanelService_ListNodes_Handler,
@@ -3756,6 +3968,21 @@ var PanelService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _PanelService_StreamConsole_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _PanelService_DownloadFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadFile",
+			Handler:       _PanelService_UploadFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBackup",
+			Handler:       _PanelService_DownloadBackup_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "plugin.proto",
 }
@@ -0,0 +1,232 @@
+package birdactyl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ServerResources struct {
+	Memory int32
+	CPU    int32
+	Disk   int32
+}
+
+type SubuserSpec struct {
+	Email       string
+	Permissions []string
+}
+
+type ServerSpec struct {
+	Name        string
+	Owner       string
+	Node        string
+	Labels      map[string]string
+	Package     string
+	Resources   ServerResources
+	Variables   map[string]string
+	Allocations []int32
+	Primary     int32
+	Subusers    []SubuserSpec
+	Databases   []string
+}
+
+type ProvisionResult struct {
+	Spec        ServerSpec
+	Server      *Server
+	Subusers    []*Subuser
+	Databases   []*Database
+	Err         error
+	RollbackErr error
+}
+
+func ParseServerSpecs(data []byte) ([]ServerSpec, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(trimmed, &node); err != nil {
+		return nil, err
+	}
+	var specs []ServerSpec
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		if err := node.Decode(&specs); err != nil {
+			return nil, err
+		}
+	} else {
+		var spec ServerSpec
+		if err := node.Decode(&spec); err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	for i := range specs {
+		if err := specs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("spec %d: %w", i, err)
+		}
+	}
+	return specs, nil
+}
+
+func (s ServerSpec) Validate() error {
+	var missing []string
+	for _, f := range []struct{ name, value string }{{"name", s.Name}, {"owner", s.Owner}, {"package", s.Package}} {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("server spec %q is missing %v", s.Name, missing)
+	}
+	if s.Primary != 0 {
+		for _, port := range s.Allocations {
+			if port == s.Primary {
+				return nil
+			}
+		}
+		return fmt.Errorf("server spec %q: primary port %d is not in allocations", s.Name, s.Primary)
+	}
+	return nil
+}
+
+func (a *API) Provision(spec ServerSpec) (*ProvisionResult, error) {
+	return a.ProvisionWith(nil, spec)
+}
+
+func (a *API) ProvisionWith(placer *Placer, spec ServerSpec) (*ProvisionResult, error) {
+	res := &ProvisionResult{Spec: spec}
+	if res.Err = spec.Validate(); res.Err != nil {
+		return res, res.Err
+	}
+
+	var undo []func() error
+	fail := func(step string, err error) (*ProvisionResult, error) {
+		res.Err = fmt.Errorf("provision %s: %s: %w", spec.Name, step, err)
+		var errs []error
+		for i := len(undo) - 1; i >= 0; i-- {
+			if err := undo[i](); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if res.RollbackErr = errors.Join(errs...); res.RollbackErr != nil {
+			log.Printf("[provision] rollback of %s incomplete: %v", spec.Name, res.RollbackErr)
+		}
+		res.Server = nil
+		return res, res.Err
+	}
+
+	resources := spec.Resources
+	if resources.Memory == 0 || resources.CPU == 0 || resources.Disk == 0 {
+		pkg, err := a.GetPackage(spec.Package)
+		if err != nil {
+			return fail("get package", err)
+		}
+		if resources.Memory == 0 {
+			resources.Memory = pkg.Memory
+		}
+		if resources.CPU == 0 {
+			resources.CPU = pkg.CPU
+		}
+		if resources.Disk == 0 {
+			resources.Disk = pkg.Disk
+		}
+	}
+
+	nodeID := spec.Node
+	if nodeID == "" {
+		if placer == nil {
+			placer = a.Placer()
+		}
+		node, err := placer.Place(PlacementRequest{Resources: resources, OwnerID: spec.Owner, Labels: spec.Labels})
+		if err != nil {
+			return fail("place server", err)
+		}
+		nodeID = node.ID
+	}
+
+	server, err := a.CreateServer(spec.Name, spec.Owner, nodeID, spec.Package, resources.Memory, resources.CPU, resources.Disk)
+	if spec.Node == "" {
+		if err != nil {
+			placer.Release(nodeID, resources)
+		} else {
+			placer.Commit(nodeID, server.ID, resources)
+		}
+	}
+	if err != nil {
+		return fail("create server", err)
+	}
+	res.Server = server
+	undo = append(undo, func() error { return a.DeleteServer(server.ID) })
+
+	if len(spec.Variables) > 0 {
+		if err := a.UpdateServerVariables(server.ID, spec.Variables); err != nil {
+			return fail("set variables", err)
+		}
+	}
+	for _, port := range spec.Allocations {
+		if err := a.AddAllocation(server.ID, port); err != nil {
+			return fail(fmt.Sprintf("add allocation %d", port), err)
+		}
+		undo = append(undo, func() error { return a.DeleteAllocation(server.ID, port) })
+	}
+	if spec.Primary != 0 {
+		if err := a.SetPrimaryAllocation(server.ID, spec.Primary); err != nil {
+			return fail("set primary allocation", err)
+		}
+	}
+	for _, su := range spec.Subusers {
+		sub, err := a.AddSubuser(server.ID, su.Email, su.Permissions)
+		if err != nil {
+			return fail("add subuser "+su.Email, err)
+		}
+		res.Subusers = append(res.Subusers, sub)
+		undo = append(undo, func() error { return a.RemoveSubuser(server.ID, sub.ID) })
+	}
+	for _, name := range spec.Databases {
+		db, err := a.CreateDatabase(server.ID, name)
+		if err != nil {
+			return fail("create database "+name, err)
+		}
+		res.Databases = append(res.Databases, db)
+		undo = append(undo, func() error { return a.DeleteDatabase(db.ID) })
+	}
+
+	if refreshed, err := a.GetServer(server.ID); err == nil {
+		res.Server = refreshed
+	}
+	return res, nil
+}
+
+func (a *API) ProvisionAll(ctx context.Context, specs []ServerSpec, concurrency int) []*ProvisionResult {
+	results := make([]*ProvisionResult, len(specs))
+	indexes := make([]int, len(specs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	placer := a.Placer()
+	var mu sync.Mutex
+	ForEach(ctx, indexes, concurrency, func(ctx context.Context, i int) error {
+		var res *ProvisionResult
+		if err := ctx.Err(); err != nil {
+			res = &ProvisionResult{Spec: specs[i], Err: err}
+		} else {
+			res, _ = a.ProvisionWith(placer, specs[i])
+		}
+		mu.Lock()
+		results[i] = res
+		mu.Unlock()
+		return nil
+	})
+	for i, res := range results {
+		if res == nil {
+			results[i] = &ProvisionResult{Spec: specs[i], Err: ctx.Err()}
+		}
+	}
+	return results
+}
@@ -0,0 +1,493 @@
+package birdactyl
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+var ErrNoRows = errors.New("birdactyl: query returned no rows")
+
+type QueryResult struct {
+	RowsAffected int64
+	LastInsertID int64
+}
+
+func queryArg(v interface{}) (*pb.QueryArg, string, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		if err != nil {
+			return nil, "", err
+		}
+		v = dv
+	}
+	if v != nil {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				v = nil
+			} else {
+				return queryArg(rv.Elem().Interface())
+			}
+		}
+	}
+
+	switch x := v.(type) {
+	case nil:
+		return &pb.QueryArg{Value: &pb.QueryArg_NullValue{NullValue: true}}, "", nil
+	case string:
+		return &pb.QueryArg{Value: &pb.QueryArg_StringValue{StringValue: x}}, x, nil
+	case []byte:
+		return &pb.QueryArg{Value: &pb.QueryArg_BytesValue{BytesValue: x}}, string(x), nil
+	case bool:
+		return &pb.QueryArg{Value: &pb.QueryArg_BoolValue{BoolValue: x}}, strconv.FormatBool(x), nil
+	case time.Time:
+		return &pb.QueryArg{Value: &pb.QueryArg_TimeValue{TimeValue: x.UnixMilli()}}, x.UTC().Format(time.RFC3339Nano), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &pb.QueryArg{Value: &pb.QueryArg_IntValue{IntValue: rv.Int()}}, strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &pb.QueryArg{Value: &pb.QueryArg_IntValue{IntValue: int64(rv.Uint())}}, strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return &pb.QueryArg{Value: &pb.QueryArg_FloatValue{FloatValue: rv.Float()}}, strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return &pb.QueryArg{Value: &pb.QueryArg_StringValue{StringValue: rv.String()}}, rv.String(), nil
+	case reflect.Bool:
+		return &pb.QueryArg{Value: &pb.QueryArg_BoolValue{BoolValue: rv.Bool()}}, strconv.FormatBool(rv.Bool()), nil
+	}
+	return nil, "", fmt.Errorf("unsupported query argument type %T", v)
+}
+
+func (a *API) query(query string, exec bool, args []interface{}) (*pb.QueryDBResponse, error) {
+	req := &pb.QueryDBRequest{Query: query, Exec: exec}
+	for _, v := range args {
+		typed, legacy, err := queryArg(v)
+		if err != nil {
+			return nil, err
+		}
+		req.TypedArgs = append(req.TypedArgs, typed)
+		req.Args = append(req.Args, legacy)
+	}
+	return a.panel.QueryDB(a.ctx(), req)
+}
+
+func (a *API) Query(query string, args ...interface{}) ([]map[string]interface{}, error) {
+	r, err := a.query(query, false, args)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]interface{}, len(r.GetRows()))
+	for i, row := range r.GetRows() {
+		dec := json.NewDecoder(bytes.NewReader(row))
+		dec.UseNumber()
+		if err := dec.Decode(&out[i]); err != nil {
+			return nil, fmt.Errorf("decode row %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+func (a *API) ExecDB(query string, args ...interface{}) (QueryResult, error) {
+	r, err := a.query(query, true, args)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	return QueryResult{RowsAffected: r.GetRowsAffected(), LastInsertID: r.GetLastInsertId()}, nil
+}
+
+func QueryInto[T any](a *API, query string, args ...interface{}) ([]T, error) {
+	rows, err := a.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, len(rows))
+	for i, row := range rows {
+		if err := scanRow(reflect.ValueOf(&out[i]).Elem(), row); err != nil {
+			return nil, fmt.Errorf("scan row %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+func QueryOne[T any](a *API, query string, args ...interface{}) (T, error) {
+	var out T
+	rows, err := QueryInto[T](a, query, args...)
+	if err != nil {
+		return out, err
+	}
+	if len(rows) == 0 {
+		return out, ErrNoRows
+	}
+	return rows[0], nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func scanRow(dst reflect.Value, row map[string]interface{}) error {
+	if dst.Kind() == reflect.Pointer {
+		dst.Set(reflect.New(dst.Type().Elem()))
+		dst = dst.Elem()
+	}
+	if dst.Kind() == reflect.Map {
+		return assignColumn(dst, row)
+	}
+	if dst.Kind() != reflect.Struct || dst.Type() == timeType {
+		if len(row) != 1 {
+			return fmt.Errorf("cannot scan %d columns into %s", len(row), dst.Type())
+		}
+		for _, v := range row {
+			return assignColumn(dst, v)
+		}
+	}
+
+	for name, field := range dbFields(dst.Type()) {
+		v, ok := row[name]
+		if !ok {
+			continue
+		}
+		if err := assignColumn(dst.FieldByIndex(field), v); err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func dbFields(t reflect.Type) map[string][]int {
+	out := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			for name, idx := range dbFields(f.Type) {
+				if _, ok := out[name]; !ok {
+					out[name] = append([]int{i}, idx...)
+				}
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+		out[name] = []int{i}
+	}
+	return out
+}
+
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func assignColumn(dst reflect.Value, v interface{}) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		p := reflect.New(dst.Type().Elem())
+		if err := assignColumn(p.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	}
+	if dst.Type() == timeType {
+		t, err := parseColumnTime(v)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	s := fmt.Sprint(v)
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+		return nil
+	case reflect.Bool:
+		switch x := v.(type) {
+		case bool:
+			dst.SetBool(x)
+		default:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return err
+			}
+			dst.SetBool(b)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+
+	if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+		dst.SetBytes([]byte(s))
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if str, ok := v.(string); ok {
+		data = []byte(str)
+	}
+	return json.Unmarshal(data, dst.Addr().Interface())
+}
+
+var columnTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02"}
+
+func parseColumnTime(v interface{}) (time.Time, error) {
+	if n, ok := v.(json.Number); ok {
+		ms, err := n.Int64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(ms), nil
+	}
+	s := fmt.Sprint(v)
+	for _, layout := range columnTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time", s)
+}
+
+var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func (a *API) Table(name string) string {
+	var b strings.Builder
+	for _, r := range a.pluginID {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return "plugin_" + b.String() + "_" + name
+}
+
+type QueryBuilder struct {
+	kind    string
+	table   string
+	columns []string
+	values  []interface{}
+	where   []string
+	args    []interface{}
+	order   []string
+	limit   int
+	offset  int
+	err     error
+}
+
+type queryBuilders struct{}
+
+var QB queryBuilders
+
+func (queryBuilders) Select(columns ...string) *QueryBuilder {
+	q := &QueryBuilder{kind: "SELECT"}
+	for _, c := range columns {
+		if c != "*" {
+			q.ident(c)
+		}
+		q.columns = append(q.columns, c)
+	}
+	return q
+}
+
+func (queryBuilders) Insert(table string) *QueryBuilder {
+	return (&QueryBuilder{kind: "INSERT"}).into(table)
+}
+
+func (queryBuilders) Update(table string) *QueryBuilder {
+	return (&QueryBuilder{kind: "UPDATE"}).into(table)
+}
+
+func (queryBuilders) DeleteFrom(table string) *QueryBuilder {
+	return (&QueryBuilder{kind: "DELETE"}).into(table)
+}
+
+func (q *QueryBuilder) ident(name string) {
+	if q.err == nil && !identRe.MatchString(name) {
+		q.err = fmt.Errorf("invalid identifier %q", name)
+	}
+}
+
+func (q *QueryBuilder) into(table string) *QueryBuilder {
+	q.ident(table)
+	q.table = table
+	return q
+}
+
+func (q *QueryBuilder) From(table string) *QueryBuilder {
+	return q.into(table)
+}
+
+func (q *QueryBuilder) Set(column string, value interface{}) *QueryBuilder {
+	q.ident(column)
+	q.columns = append(q.columns, column)
+	q.values = append(q.values, value)
+	return q
+}
+
+func (q *QueryBuilder) Where(cond string, args ...interface{}) *QueryBuilder {
+	if n := strings.Count(cond, "?"); n != len(args) && q.err == nil {
+		q.err = fmt.Errorf("where %q expects %d args, got %d", cond, n, len(args))
+	}
+	q.where = append(q.where, cond)
+	q.args = append(q.args, args...)
+	return q
+}
+
+func (q *QueryBuilder) Eq(column string, value interface{}) *QueryBuilder {
+	q.ident(column)
+	if value == nil {
+		return q.Where(column + " IS NULL")
+	}
+	return q.Where(column+" = ?", value)
+}
+
+func (q *QueryBuilder) In(column string, values ...interface{}) *QueryBuilder {
+	q.ident(column)
+	if len(values) == 0 {
+		return q.Where("1 = 0")
+	}
+	return q.Where(column+" IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")+")", values...)
+}
+
+func (q *QueryBuilder) OrderBy(column string, desc bool) *QueryBuilder {
+	q.ident(column)
+	if desc {
+		column += " DESC"
+	}
+	q.order = append(q.order, column)
+	return q
+}
+
+func (q *QueryBuilder) Limit(n int) *QueryBuilder {
+	q.limit = n
+	return q
+}
+
+func (q *QueryBuilder) Offset(n int) *QueryBuilder {
+	q.offset = n
+	return q
+}
+
+func (q *QueryBuilder) Build() (string, []interface{}, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	if q.table == "" {
+		return "", nil, fmt.Errorf("query has no table")
+	}
+
+	var b strings.Builder
+	var args []interface{}
+	switch q.kind {
+	case "SELECT":
+		cols := "*"
+		if len(q.columns) > 0 {
+			cols = strings.Join(q.columns, ", ")
+		}
+		fmt.Fprintf(&b, "SELECT %s FROM %s", cols, q.table)
+	case "INSERT":
+		if len(q.columns) == 0 {
+			return "", nil, fmt.Errorf("insert into %s has no values", q.table)
+		}
+		fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES (%s)", q.table, strings.Join(q.columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(q.columns)), ", "))
+		return b.String(), q.values, nil
+	case "UPDATE":
+		if len(q.columns) == 0 {
+			return "", nil, fmt.Errorf("update %s has no values", q.table)
+		}
+		sets := make([]string, len(q.columns))
+		for i, c := range q.columns {
+			sets[i] = c + " = ?"
+		}
+		fmt.Fprintf(&b, "UPDATE %s SET %s", q.table, strings.Join(sets, ", "))
+		args = append(args, q.values...)
+	case "DELETE":
+		fmt.Fprintf(&b, "DELETE FROM %s", q.table)
+	}
+
+	if len(q.where) > 0 {
+		b.WriteString(" WHERE " + strings.Join(q.where, " AND "))
+		args = append(args, q.args...)
+	}
+	if len(q.order) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(q.order, ", "))
+	}
+	if q.limit > 0 {
+		fmt.Fprintf(&b, " LIMIT %d", q.limit)
+	}
+	if q.offset > 0 {
+		fmt.Fprintf(&b, " OFFSET %d", q.offset)
+	}
+	return b.String(), args, nil
+}
+
+func (q *QueryBuilder) Exec(a *API) (QueryResult, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return QueryResult{}, err
+	}
+	return a.ExecDB(query, args...)
+}
+
+func Fetch[T any](a *API, q *QueryBuilder) ([]T, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return QueryInto[T](a, query, args...)
+}
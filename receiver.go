@@ -0,0 +1,207 @@
+package birdactyl
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrWebhookSignature = errors.New("birdactyl: webhook signature invalid")
+	ErrWebhookReplay    = errors.New("birdactyl: webhook timestamp outside replay window")
+)
+
+var WebhookIdempotencyHeaders = []string{"Idempotency-Key", WebhookDeliveryHeader, "X-GitHub-Delivery", "X-Gitlab-Event-UUID", "X-Request-Id"}
+
+var WebhookIdempotencyTTL = 24 * time.Hour
+
+var WebhookIdempotencySize = 10000
+
+type WebhookVerifier interface {
+	Verify(req Request) error
+}
+
+type WebhookVerifierFunc func(req Request) error
+
+func (f WebhookVerifierFunc) Verify(req Request) error {
+	return f(req)
+}
+
+func HMACSHA256Verifier(header, secret, prefix string) WebhookVerifier {
+	return hmacVerifier(sha256.New, header, secret, prefix)
+}
+
+func HMACSHA1Verifier(header, secret, prefix string) WebhookVerifier {
+	return hmacVerifier(sha1.New, header, secret, prefix)
+}
+
+func GitHubVerifier(secret string) WebhookVerifier {
+	return HMACSHA256Verifier("X-Hub-Signature-256", secret, "sha256=")
+}
+
+func TokenVerifier(header, token string) WebhookVerifier {
+	return WebhookVerifierFunc(func(req Request) error {
+		if !hmac.Equal([]byte(req.Header(header)), []byte(token)) {
+			return ErrWebhookSignature
+		}
+		return nil
+	})
+}
+
+func BirdactylHeaderVerifier(signatureHeader, timestampHeader, secret string, window time.Duration) WebhookVerifier {
+	return WebhookVerifierFunc(func(req Request) error {
+		ts, err := strconv.ParseInt(req.Header(timestampHeader), 10, 64)
+		if err != nil {
+			return ErrWebhookSignature
+		}
+		at := time.Unix(ts, 0)
+		if err := checkReplayWindow(at, window); err != nil {
+			return err
+		}
+		if !hmac.Equal([]byte(req.Header(signatureHeader)), []byte(SignWebhook(secret, at, req.RawBody))) {
+			return ErrWebhookSignature
+		}
+		return nil
+	})
+}
+
+func BirdactylVerifier(secret string, window time.Duration) WebhookVerifier {
+	return BirdactylHeaderVerifier(WebhookSignatureHeader, WebhookTimestampHeader, secret, window)
+}
+
+func StripeVerifier(secret string, window time.Duration) WebhookVerifier {
+	return WebhookVerifierFunc(func(req Request) error {
+		var ts string
+		var sigs []string
+		for _, part := range strings.Split(req.Header("Stripe-Signature"), ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch k {
+			case "t":
+				ts = v
+			case "v1":
+				sigs = append(sigs, v)
+			}
+		}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil || len(sigs) == 0 {
+			return ErrWebhookSignature
+		}
+		if err := checkReplayWindow(time.Unix(sec, 0), window); err != nil {
+			return err
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "."))
+		mac.Write(req.RawBody)
+		expected := hex.EncodeToString(mac.Sum(nil))
+		for _, sig := range sigs {
+			if hmac.Equal([]byte(sig), []byte(expected)) {
+				return nil
+			}
+		}
+		return ErrWebhookSignature
+	})
+}
+
+func AnyVerifier(verifiers ...WebhookVerifier) WebhookVerifier {
+	return WebhookVerifierFunc(func(req Request) error {
+		err := ErrWebhookSignature
+		for _, v := range verifiers {
+			if err = v.Verify(req); err == nil {
+				return nil
+			}
+		}
+		return err
+	})
+}
+
+func hmacVerifier(h func() hash.Hash, header, secret, prefix string) WebhookVerifier {
+	return WebhookVerifierFunc(func(req Request) error {
+		got := req.Header(header)
+		if !strings.HasPrefix(got, prefix) {
+			return ErrWebhookSignature
+		}
+		mac := hmac.New(h, []byte(secret))
+		mac.Write(req.RawBody)
+		expected := hex.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(strings.ToLower(got[len(prefix):])), []byte(expected)) {
+			return ErrWebhookSignature
+		}
+		return nil
+	})
+}
+
+func checkReplayWindow(at time.Time, window time.Duration) error {
+	if window <= 0 {
+		return nil
+	}
+	if d := time.Since(at); d > window || d < -window {
+		return ErrWebhookReplay
+	}
+	return nil
+}
+
+func (r Request) Header(name string) string {
+	if v, ok := r.Headers[name]; ok {
+		return v
+	}
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (p *Plugin) Webhook(path string, verifier WebhookVerifier, handler RouteHandler) *Plugin {
+	var mu sync.Mutex
+	inflight := make(map[string]bool)
+	seen := newCache(CacheOptions{TTL: WebhookIdempotencyTTL, MaxEntries: WebhookIdempotencySize}, func(v struct{}) struct{} { return v })
+
+	return p.Route("POST", path, func(req Request) Response {
+		if verifier != nil {
+			if err := verifier.Verify(req); err != nil {
+				return Error(401, err.Error())
+			}
+		}
+
+		id := ""
+		for _, h := range WebhookIdempotencyHeaders {
+			if id = req.Header(h); id != "" {
+				break
+			}
+		}
+		if id == "" {
+			return handler(req)
+		}
+
+		mu.Lock()
+		if inflight[id] {
+			mu.Unlock()
+			return JSON(map[string]interface{}{"duplicate": true}).WithStatus(409)
+		}
+		inflight[id] = true
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			delete(inflight, id)
+			mu.Unlock()
+		}()
+
+		if seen.contains(id) {
+			return JSON(map[string]interface{}{"duplicate": true})
+		}
+
+		resp := handler(req)
+		if resp.Status < 300 {
+			seen.set(id, struct{}{})
+		}
+		return resp
+	})
+}
@@ -0,0 +1,118 @@
+package birdactyl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+var ErrBackupUnverifiable = errors.New("birdactyl: backup has no sha256 checksum to verify against")
+
+type RestoreOptions struct {
+	Verify   bool
+	Truncate bool
+	Stop     *StopPolicy
+	Restart  bool
+}
+
+func backupSHA256(b *Backup) string {
+	algo, sum, ok := strings.Cut(b.Checksum, ":")
+	if !ok {
+		return strings.ToLower(b.Checksum)
+	}
+	if strings.EqualFold(algo, "sha256") {
+		return strings.ToLower(sum)
+	}
+	return ""
+}
+
+func (a *API) OpenBackup(serverID, backupID string) (*FileReader, error) {
+	backup, err := a.GetBackup(serverID, backupID)
+	if err != nil {
+		return nil, err
+	}
+	return a.openBackup(serverID, backup)
+}
+
+func (a *API) openBackup(serverID string, backup *Backup) (*FileReader, error) {
+	r, err := a.openReader(backup.Name, backupSHA256(backup), func(ctx context.Context, offset int64) (pb.PanelService_DownloadFileClient, error) {
+		return a.panel.DownloadBackup(ctx, &pb.DownloadBackupRequest{
+			ServerId:  serverID,
+			BackupId:  backup.ID,
+			Offset:    offset,
+			ChunkSize: int32(TransferChunkSize),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if backup.Size > 0 {
+		r.size = backup.Size
+	}
+	return r, nil
+}
+
+func (a *API) DownloadBackup(serverID, backupID string, w io.Writer) (int64, error) {
+	r, err := a.OpenBackup(serverID, backupID)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+func (a *API) VerifyBackup(serverID, backupID string) error {
+	backup, err := a.GetBackup(serverID, backupID)
+	if err != nil {
+		return err
+	}
+	if backupSHA256(backup) == "" {
+		return fmt.Errorf("backup %s: %w", backupID, ErrBackupUnverifiable)
+	}
+	r, err := a.openBackup(serverID, backup)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("backup %s: %w", backupID, err)
+	}
+	return nil
+}
+
+func (a *API) RestoreBackupWith(ctx context.Context, serverID, backupID string, opts RestoreOptions) error {
+	if opts.Verify {
+		if err := a.WithContext(ctx).VerifyBackup(serverID, backupID); err != nil {
+			return err
+		}
+	}
+
+	stats, err := a.WithContext(ctx).GetServerStats(serverID)
+	if err != nil {
+		return err
+	}
+	wasRunning := stats.State != ServerStateOffline
+	if wasRunning {
+		policy := DefaultStopPolicy
+		if opts.Stop != nil {
+			policy = *opts.Stop
+		}
+		if _, err := a.StopAndWait(ctx, serverID, policy); err != nil {
+			return fmt.Errorf("stop before restore: %w", err)
+		}
+	}
+
+	if err := a.WithContext(ctx).RestoreBackup(serverID, backupID, opts.Truncate); err != nil {
+		return err
+	}
+	if wasRunning && opts.Restart {
+		if _, err := a.StartAndWait(ctx, serverID); err != nil {
+			return fmt.Errorf("start after restore: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,587 @@
+package birdactyl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ServerFSListingTTL = 2 * time.Second
+
+type ServerFS struct {
+	api      *API
+	serverID string
+	mu       sync.Mutex
+	listings map[string]serverListing
+}
+
+type serverListing struct {
+	entries []fs.DirEntry
+	at      time.Time
+}
+
+func (a *API) ServerFS(serverID string) *ServerFS {
+	return &ServerFS{api: a, serverID: serverID}
+}
+
+func (f *ServerFS) remote(name string) string {
+	if name == "." {
+		return "/"
+	}
+	return "/" + name
+}
+
+func fsError(op, name string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		err = fs.ErrNotExist
+	case codes.PermissionDenied:
+		err = fs.ErrPermission
+	case codes.Unknown:
+		msg := strings.ToLower(status.Convert(err).Message())
+		if strings.Contains(msg, "not found") || strings.Contains(msg, "no such file") || strings.Contains(msg, "does not exist") {
+			err = fs.ErrNotExist
+		}
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+func (f *ServerFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	r, err := f.api.panel.ListFiles(f.api.ctx(), &pb.FilePathRequest{ServerId: f.serverID, Path: f.remote(name)})
+	if err != nil {
+		return nil, fsError("readdir", name, err)
+	}
+	entries := make([]fs.DirEntry, len(r.GetFiles()))
+	for i, fi := range r.GetFiles() {
+		entries[i] = fs.FileInfoToDirEntry(serverFileInfo{fileFromProto(fi)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	f.mu.Lock()
+	if f.listings == nil {
+		f.listings = make(map[string]serverListing)
+	}
+	f.listings[name] = serverListing{entries: entries, at: time.Now()}
+	f.mu.Unlock()
+	return entries, nil
+}
+
+func (f *ServerFS) listing(name string) ([]fs.DirEntry, error) {
+	f.mu.Lock()
+	l, ok := f.listings[name]
+	f.mu.Unlock()
+	if ok && time.Since(l.at) < ServerFSListingTTL {
+		return l.entries, nil
+	}
+	return f.ReadDir(name)
+}
+
+func (f *ServerFS) forget(name string) {
+	f.mu.Lock()
+	delete(f.listings, path.Dir(name))
+	f.mu.Unlock()
+}
+
+func (f *ServerFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return serverFileInfo{&File{Name: ".", IsDir: true}}, nil
+	}
+	entries, err := f.listing(path.Dir(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err.(*fs.PathError).Err}
+	}
+	base := path.Base(name)
+	for _, e := range entries {
+		if e.Name() == base {
+			return e.Info()
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (f *ServerFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	r, err := f.api.panel.ReadFile(f.api.ctx(), &pb.FilePathRequest{ServerId: f.serverID, Path: f.remote(name)})
+	if err != nil {
+		return nil, fsError("read", name, err)
+	}
+	return r.GetContent(), nil
+}
+
+func (f *ServerFS) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	defer f.forget(name)
+	if _, err := f.api.panel.WriteFile(f.api.ctx(), &pb.WriteFileRequest{ServerId: f.serverID, Path: f.remote(name), Content: data}); err != nil {
+		return fsError("write", name, err)
+	}
+	return nil
+}
+
+func (f *ServerFS) Open(name string) (fs.File, error) {
+	info, err := f.Stat(name)
+	if err != nil {
+		if pe, ok := err.(*fs.PathError); ok {
+			pe.Op = "open"
+		}
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := f.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &serverDir{info: info, entries: entries}, nil
+	}
+	data, err := f.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &serverFile{info: info, Reader: bytes.NewReader(data)}, nil
+}
+
+func (f *ServerFS) WalkDir(root string, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(f, root, fn)
+}
+
+func (f *ServerFS) Glob(pattern string) ([]string, error) {
+	return fs.Glob(serverFSNoGlob{f}, pattern)
+}
+
+type serverFSNoGlob struct{ f *ServerFS }
+
+func (g serverFSNoGlob) Open(name string) (fs.File, error)          { return g.f.Open(name) }
+func (g serverFSNoGlob) Stat(name string) (fs.FileInfo, error)      { return g.f.Stat(name) }
+func (g serverFSNoGlob) ReadDir(name string) ([]fs.DirEntry, error) { return g.f.ReadDir(name) }
+
+func (f *ServerFS) GlobRecursive(pattern string) ([]string, error) {
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, err
+	}
+	parts := strings.Split(pattern, "/")
+	root := "."
+	for i, p := range parts {
+		if strings.ContainsAny(p, "*?[\\") || i == len(parts)-1 {
+			if i > 0 {
+				root = path.Join(parts[:i]...)
+			}
+			break
+		}
+	}
+
+	recursive := strings.Contains(pattern, "**")
+	var out []string
+	err := fs.WalkDir(f, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == root {
+				return nil
+			}
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		segments := strings.Split(name, "/")
+		if matchGlob(parts, segments) {
+			out = append(out, name)
+		}
+		if d.IsDir() && !recursive && len(segments) >= len(parts) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return out, err
+}
+
+func matchGlob(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+type GrepOptions struct {
+	Include     string
+	IgnoreCase  bool
+	MaxFileSize int64
+	MaxMatches  int
+	Concurrency int
+}
+
+type GrepMatch struct {
+	Path string
+	Line int
+	Text string
+}
+
+func (f *ServerFS) Grep(root, pattern string, opts GrepOptions) ([]GrepMatch, error) {
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = 4 << 20
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+
+	var files []string
+	err = fs.WalkDir(f, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if opts.Include != "" {
+			if ok, _ := path.Match(opts.Include, d.Name()); !ok {
+				return nil
+			}
+		}
+		if info, err := d.Info(); err == nil && info.Size() > opts.MaxFileSize {
+			return nil
+		}
+		files = append(files, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var out []GrepMatch
+	limited := false
+	ctx, cancel := context.WithCancel(f.api.ctx())
+	defer cancel()
+	err = ForEach(ctx, files, opts.Concurrency, func(ctx context.Context, name string) error {
+		data, err := f.api.WithContext(ctx).ServerFS(f.serverID).ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			if !re.MatchString(line) {
+				continue
+			}
+			mu.Lock()
+			if opts.MaxMatches > 0 && len(out) >= opts.MaxMatches {
+				limited = true
+				mu.Unlock()
+				cancel()
+				return nil
+			}
+			out = append(out, GrepMatch{Path: name, Line: i + 1, Text: strings.TrimRight(line, "\r")})
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil && !limited {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out, nil
+}
+
+func (f *ServerFS) Diff(a, b string) (string, error) {
+	left, err := f.ReadFile(a)
+	if err != nil {
+		return "", err
+	}
+	right, err := f.ReadFile(b)
+	if err != nil {
+		return "", err
+	}
+	return Diff(a, b, left, right), nil
+}
+
+func (f *ServerFS) DiffContent(name string, content []byte) (string, error) {
+	current, err := f.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return Diff(name, name, current, content), nil
+}
+
+func Diff(nameA, nameB string, a, b []byte) string {
+	x := splitLines(string(a))
+	y := splitLines(string(b))
+
+	type edit struct {
+		op   byte
+		text string
+		i, j int
+	}
+	var edits []edit
+	i, j := 0, 0
+	for _, op := range diffOps(x, y) {
+		switch op {
+		case ' ':
+			edits = append(edits, edit{' ', x[i], i, j})
+			i++
+			j++
+		case '-':
+			edits = append(edits, edit{'-', x[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', y[j], i, j})
+			j++
+		}
+	}
+
+	const diffContext = 3
+	var out strings.Builder
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			k++
+			continue
+		}
+		start := max(k-diffContext, 0)
+		end := k
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].op == ' ' {
+				run++
+			}
+			if run == len(edits) || run-end > 2*diffContext {
+				end = min(end+diffContext, len(edits))
+				break
+			}
+			end = run
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+		var oldLen, newLen int
+		for _, e := range edits[start:end] {
+			if e.op != '+' {
+				oldLen++
+			}
+			if e.op != '-' {
+				newLen++
+			}
+		}
+		oldStart, newStart := edits[start].i, edits[start].j
+		if oldLen > 0 {
+			oldStart++
+		}
+		if newLen > 0 {
+			newStart++
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLen, newStart, newLen)
+		for _, e := range edits[start:end] {
+			out.WriteByte(e.op)
+			out.WriteString(e.text)
+			out.WriteByte('\n')
+		}
+		k = end
+	}
+	return out.String()
+}
+
+func diffOps(x, y []string) []byte {
+	ids := make(map[string]int)
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, l := range lines {
+			id, ok := ids[l]
+			if !ok {
+				id = len(ids)
+				ids[l] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+	xs, ys := intern(x), intern(y)
+
+	prefix := 0
+	for prefix < len(xs) && prefix < len(ys) && xs[prefix] == ys[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(xs)-prefix && suffix < len(ys)-prefix && xs[len(xs)-1-suffix] == ys[len(ys)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]byte, 0, len(xs)+len(ys))
+	ops = append(ops, bytes.Repeat([]byte{' '}, prefix)...)
+	ops = hirschberg(xs[prefix:len(xs)-suffix], ys[prefix:len(ys)-suffix], ops)
+	return append(ops, bytes.Repeat([]byte{' '}, suffix)...)
+}
+
+func hirschberg(x, y []int, ops []byte) []byte {
+	switch {
+	case len(x) == 0:
+		return append(ops, bytes.Repeat([]byte{'+'}, len(y))...)
+	case len(y) == 0:
+		return append(ops, bytes.Repeat([]byte{'-'}, len(x))...)
+	case len(x) == 1:
+		for j, v := range y {
+			if v == x[0] {
+				ops = append(ops, bytes.Repeat([]byte{'+'}, j)...)
+				ops = append(ops, ' ')
+				return append(ops, bytes.Repeat([]byte{'+'}, len(y)-j-1)...)
+			}
+		}
+		ops = append(ops, '-')
+		return append(ops, bytes.Repeat([]byte{'+'}, len(y))...)
+	}
+
+	mid := len(x) / 2
+	head, tail := lcsPrefix(x[:mid], y), lcsSuffix(x[mid:], y)
+	split, best := 0, -1
+	for k := range head {
+		if score := head[k] + tail[k]; score > best {
+			split, best = k, score
+		}
+	}
+	ops = hirschberg(x[:mid], y[:split], ops)
+	return hirschberg(x[mid:], y[split:], ops)
+}
+
+func lcsPrefix(x, y []int) []int {
+	prev, cur := make([]int, len(y)+1), make([]int, len(y)+1)
+	for i := range x {
+		for j := range y {
+			if x[i] == y[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+func lcsSuffix(x, y []int) []int {
+	prev, cur := make([]int, len(y)+1), make([]int, len(y)+1)
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				cur[j] = prev[j+1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type serverFileInfo struct {
+	f *File
+}
+
+func (i serverFileInfo) Name() string       { return path.Base(i.f.Name) }
+func (i serverFileInfo) Size() int64        { return i.f.Size }
+func (i serverFileInfo) IsDir() bool        { return i.f.IsDir }
+func (i serverFileInfo) Sys() interface{}   { return i.f }
+func (i serverFileInfo) ModTime() time.Time { return parseFileTime(i.f.ModTime) }
+
+func (i serverFileInfo) Mode() fs.FileMode {
+	if i.f.IsDir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+func parseFileTime(s string) time.Time {
+	for _, layout := range columnTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+type serverFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *serverFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *serverFile) Close() error               { return nil }
+
+type serverDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *serverDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *serverDir) Close() error               { return nil }
+
+func (d *serverDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+func (d *serverDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
@@ -0,0 +1,473 @@
+package birdactyl
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Metric string
+
+const (
+	MetricCPUPercent  Metric = "CPUPercent"
+	MetricMemoryBytes Metric = "MemoryBytes"
+	MetricMemoryLimit Metric = "MemoryLimit"
+	MetricDiskBytes   Metric = "DiskBytes"
+	MetricNetworkRx   Metric = "NetworkRx"
+	MetricNetworkTx   Metric = "NetworkTx"
+	MetricRxRate      Metric = "RxRate"
+	MetricTxRate      Metric = "TxRate"
+)
+
+type StatsSample struct {
+	Time        time.Time
+	State       string
+	MemoryBytes int64
+	MemoryLimit int64
+	CPUPercent  float64
+	DiskBytes   int64
+	NetworkRx   int64
+	NetworkTx   int64
+	RxRate      float64
+	TxRate      float64
+}
+
+func (s StatsSample) Value(m Metric) (float64, bool) {
+	switch m {
+	case MetricCPUPercent:
+		return s.CPUPercent, true
+	case MetricMemoryBytes:
+		return float64(s.MemoryBytes), true
+	case MetricMemoryLimit:
+		return float64(s.MemoryLimit), true
+	case MetricDiskBytes:
+		return float64(s.DiskBytes), true
+	case MetricNetworkRx:
+		return float64(s.NetworkRx), true
+	case MetricNetworkTx:
+		return float64(s.NetworkTx), true
+	case MetricRxRate:
+		return s.RxRate, true
+	case MetricTxRate:
+		return s.TxRate, true
+	}
+	return 0, false
+}
+
+type StatsSummary struct {
+	Count int
+	Min   float64
+	Max   float64
+	Avg   float64
+	Last  float64
+}
+
+type StatsOptions struct {
+	Interval     time.Duration
+	Capacity     int
+	Servers      []string
+	Filter       func(*Server) bool
+	PersistDir   string
+	PersistEvery time.Duration
+}
+
+var DefaultStatsOptions = StatsOptions{Interval: 15 * time.Second, Capacity: 5760, PersistEvery: time.Minute}
+
+type StatsCollector struct {
+	mu       sync.RWMutex
+	async    *AsyncAPI
+	opts     StatsOptions
+	series   map[string]*statsRing
+	explicit map[string]bool
+	hooks    []func(serverID string, s StatsSample)
+	stopCh   chan struct{}
+	done     chan struct{}
+	persist  sync.Mutex
+}
+
+type statsRing struct {
+	buf  []StatsSample
+	head int
+	size int
+	max  int
+}
+
+func NewStatsCollector(async *AsyncAPI, opts StatsOptions) *StatsCollector {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultStatsOptions.Interval
+	}
+	if opts.Capacity <= 0 {
+		opts.Capacity = DefaultStatsOptions.Capacity
+	}
+	if opts.PersistEvery <= 0 {
+		opts.PersistEvery = DefaultStatsOptions.PersistEvery
+	}
+	c := &StatsCollector{async: async, opts: opts, series: make(map[string]*statsRing), explicit: make(map[string]bool)}
+	for _, id := range opts.Servers {
+		c.explicit[id] = true
+	}
+	return c
+}
+
+func (p *Plugin) CollectStats(opts StatsOptions) *Plugin {
+	p.statsOpts = &opts
+	return p
+}
+
+func (p *Plugin) Stats() *StatsCollector {
+	return p.stats
+}
+
+func (c *StatsCollector) OnSample(fn func(serverID string, s StatsSample)) *StatsCollector {
+	c.mu.Lock()
+	c.hooks = append(c.hooks, fn)
+	c.mu.Unlock()
+	return c
+}
+
+func (c *StatsCollector) Track(serverIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range serverIDs {
+		c.explicit[id] = true
+	}
+}
+
+func (c *StatsCollector) Untrack(serverIDs ...string) {
+	c.persist.Lock()
+	defer c.persist.Unlock()
+	c.mu.Lock()
+	for _, id := range serverIDs {
+		delete(c.explicit, id)
+		delete(c.series, id)
+	}
+	c.mu.Unlock()
+	if c.opts.PersistDir == "" {
+		return
+	}
+	for _, id := range serverIDs {
+		if err := os.Remove(c.historyPath(id)); err != nil && !os.IsNotExist(err) {
+			log.Printf("[stats] failed to remove history %s: %v", id, err)
+		}
+	}
+}
+
+func (c *StatsCollector) Start() {
+	c.mu.Lock()
+	if c.stopCh != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.stopCh = make(chan struct{})
+	c.done = make(chan struct{})
+	stop, done := c.stopCh, c.done
+	c.mu.Unlock()
+
+	c.load()
+	go func() {
+		defer close(done)
+		tick := time.NewTicker(c.opts.Interval)
+		persist := time.NewTicker(c.opts.PersistEvery)
+		defer tick.Stop()
+		defer persist.Stop()
+
+		targets := c.targets()
+		refresh := time.Now()
+		c.sample(targets)
+		for {
+			select {
+			case <-tick.C:
+				if time.Since(refresh) >= time.Minute {
+					targets = c.targets()
+					refresh = time.Now()
+				}
+				c.sample(targets)
+			case <-persist.C:
+				c.save()
+			case <-stop:
+				c.save()
+				return
+			}
+		}
+	}()
+}
+
+func (c *StatsCollector) Stop() {
+	c.mu.Lock()
+	if c.stopCh == nil {
+		c.mu.Unlock()
+		return
+	}
+	close(c.stopCh)
+	c.stopCh = nil
+	done := c.done
+	c.mu.Unlock()
+	<-done
+}
+
+func (c *StatsCollector) historyPath(serverID string) string {
+	return filepath.Join(c.opts.PersistDir, url.PathEscape(serverID)+".json")
+}
+
+func (c *StatsCollector) targets() []string {
+	c.mu.RLock()
+	explicit := make(map[string]bool, len(c.explicit))
+	ids := make([]string, 0, len(c.explicit))
+	for id := range c.explicit {
+		explicit[id] = true
+		ids = append(ids, id)
+	}
+	c.mu.RUnlock()
+	if len(ids) > 0 && c.opts.Filter == nil {
+		return ids
+	}
+
+	servers, err := c.async.ListServers().Get()
+	if err != nil {
+		log.Printf("[stats] failed to list servers: %v", err)
+		return ids
+	}
+	out := make([]string, 0, len(servers))
+	for _, s := range servers {
+		if len(explicit) > 0 && !explicit[s.ID] {
+			continue
+		}
+		if c.opts.Filter != nil && !c.opts.Filter(s) {
+			continue
+		}
+		out = append(out, s.ID)
+	}
+	return out
+}
+
+func (c *StatsCollector) sample(ids []string) {
+	futures := make([]*Future[*ServerStats], len(ids))
+	for i, id := range ids {
+		futures[i] = c.async.GetServerStats(id)
+	}
+	for i, f := range futures {
+		stats, err := f.Get()
+		if err != nil {
+			continue
+		}
+		c.Record(ids[i], time.Now(), stats)
+	}
+}
+
+func (c *StatsCollector) Record(serverID string, at time.Time, stats *ServerStats) StatsSample {
+	s := StatsSample{
+		Time:        at,
+		State:       stats.State,
+		MemoryBytes: stats.MemoryBytes,
+		MemoryLimit: stats.MemoryLimit,
+		CPUPercent:  stats.CPUPercent,
+		DiskBytes:   stats.DiskBytes,
+		NetworkRx:   stats.NetworkRx,
+		NetworkTx:   stats.NetworkTx,
+	}
+
+	c.mu.Lock()
+	ring, ok := c.series[serverID]
+	if !ok {
+		ring = &statsRing{max: c.opts.Capacity}
+		c.series[serverID] = ring
+	}
+	if prev, ok := ring.last(); ok {
+		if dt := s.Time.Sub(prev.Time).Seconds(); dt > 0 {
+			s.RxRate = counterRate(prev.NetworkRx, s.NetworkRx, dt)
+			s.TxRate = counterRate(prev.NetworkTx, s.NetworkTx, dt)
+		}
+	}
+	ring.push(s)
+	hooks := c.hooks
+	c.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn(serverID, s)
+	}
+	return s
+}
+
+func counterRate(prev, cur int64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+func (c *StatsCollector) Servers() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]string, 0, len(c.series))
+	for id := range c.series {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *StatsCollector) Latest(serverID string) (StatsSample, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ring, ok := c.series[serverID]
+	if !ok {
+		return StatsSample{}, false
+	}
+	return ring.last()
+}
+
+func (c *StatsCollector) Samples(serverID string, window time.Duration) []StatsSample {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ring, ok := c.series[serverID]
+	if !ok {
+		return nil
+	}
+	all := ring.all()
+	if window <= 0 {
+		return all
+	}
+	cutoff := time.Now().Add(-window)
+	i := sort.Search(len(all), func(i int) bool { return !all[i].Time.Before(cutoff) })
+	return all[i:]
+}
+
+func (c *StatsCollector) Summary(serverID string, metric Metric, window time.Duration) StatsSummary {
+	var sum StatsSummary
+	for _, s := range c.Samples(serverID, window) {
+		v, ok := s.Value(metric)
+		if !ok {
+			break
+		}
+		if sum.Count == 0 || v < sum.Min {
+			sum.Min = v
+		}
+		if sum.Count == 0 || v > sum.Max {
+			sum.Max = v
+		}
+		sum.Avg += v
+		sum.Last = v
+		sum.Count++
+	}
+	if sum.Count > 0 {
+		sum.Avg /= float64(sum.Count)
+	}
+	return sum
+}
+
+func (c *StatsCollector) Percentile(serverID string, metric Metric, window time.Duration, p float64) float64 {
+	samples := c.Samples(serverID, window)
+	values := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		if v, ok := s.Value(metric); ok {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	if !(p > 0) {
+		p = 0
+	} else if p > 100 {
+		p = 100
+	}
+	sort.Float64s(values)
+	rank := p / 100 * float64(len(values)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	return values[lo] + (values[hi]-values[lo])*(rank-float64(lo))
+}
+
+func (c *StatsCollector) load() {
+	if c.opts.PersistDir == "" {
+		return
+	}
+	entries, err := os.ReadDir(c.opts.PersistDir)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(c.opts.PersistDir, name))
+		if err != nil {
+			continue
+		}
+		var samples []StatsSample
+		if err := json.Unmarshal(data, &samples); err != nil {
+			log.Printf("[stats] discarding unreadable history %s: %v", name, err)
+			continue
+		}
+		id, err := url.PathUnescape(name[:len(name)-len(".json")])
+		if err != nil {
+			continue
+		}
+		ring := &statsRing{max: c.opts.Capacity}
+		for _, s := range samples {
+			ring.push(s)
+		}
+		c.series[id] = ring
+	}
+}
+
+func (c *StatsCollector) save() {
+	if c.opts.PersistDir == "" {
+		return
+	}
+	if err := os.MkdirAll(c.opts.PersistDir, 0755); err != nil {
+		log.Printf("[stats] failed to create %s: %v", c.opts.PersistDir, err)
+		return
+	}
+	c.persist.Lock()
+	defer c.persist.Unlock()
+	c.mu.RLock()
+	snapshot := make(map[string][]StatsSample, len(c.series))
+	for id, ring := range c.series {
+		snapshot[id] = ring.all()
+	}
+	c.mu.RUnlock()
+
+	for id, samples := range snapshot {
+		data, err := json.Marshal(samples)
+		if err != nil {
+			continue
+		}
+		if err := writeFileAtomic(c.historyPath(id), data, 0644); err != nil {
+			log.Printf("[stats] failed to persist %s: %v", id, err)
+		}
+	}
+}
+
+func (r *statsRing) push(s StatsSample) {
+	if len(r.buf) < r.max {
+		r.buf = append(r.buf, s)
+		r.size++
+		return
+	}
+	r.buf[r.head] = s
+	r.head = (r.head + 1) % len(r.buf)
+}
+
+func (r *statsRing) last() (StatsSample, bool) {
+	if r.size == 0 {
+		return StatsSample{}, false
+	}
+	return r.buf[(r.head+r.size-1)%len(r.buf)], true
+}
+
+func (r *statsRing) all() []StatsSample {
+	out := make([]StatsSample, r.size)
+	for i := range out {
+		out[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+	return out
+}
@@ -0,0 +1,461 @@
+package birdactyl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var ErrStoreClosed = errors.New("birdactyl: store is closed")
+
+const storeLogName = "store.log"
+
+type Store struct {
+	mu           sync.RWMutex
+	dir          string
+	file         *os.File
+	size         int64
+	docs         map[string]map[string]json.RawMessage
+	indexes      map[string]map[string]*storeIndex
+	records      int
+	CompactAfter int
+	SyncWrites   bool
+}
+
+type storeIndex struct {
+	key    func(json.RawMessage) (string, bool)
+	values map[string]map[string]bool
+}
+
+type storeOp struct {
+	Op         string          `json:"op"`
+	Collection string          `json:"c,omitempty"`
+	ID         string          `json:"id,omitempty"`
+	Doc        json.RawMessage `json:"doc,omitempty"`
+	Ops        []storeOp       `json:"ops,omitempty"`
+}
+
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		dir:          dir,
+		docs:         make(map[string]map[string]json.RawMessage),
+		indexes:      make(map[string]map[string]*storeIndex),
+		CompactAfter: 1000,
+		SyncWrites:   true,
+	}
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, storeLogName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	s.file, s.size = f, info.Size()
+	return s, nil
+}
+
+func (p *Plugin) Store() (*Store, error) {
+	p.storeOnce.Do(func() {
+		p.store, p.storeErr = OpenStore(p.DataPath("store"))
+	})
+	return p.store, p.storeErr
+}
+
+func (s *Store) recover() error {
+	path := filepath.Join(s.dir, storeLogName)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var good int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				log.Printf("[store] dropping truncated record at offset %d", good)
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		op, ok := decodeStoreRecord(line)
+		if !ok {
+			log.Printf("[store] dropping corrupt record at offset %d", good)
+			break
+		}
+		s.apply(op)
+		s.records++
+		good += int64(len(line))
+	}
+
+	if info, err := f.Stat(); err == nil && info.Size() > good {
+		return os.Truncate(path, good)
+	}
+	return nil
+}
+
+func encodeStoreRecord(op storeOp) ([]byte, error) {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+	line := make([]byte, 0, len(data)+10)
+	line = append(line, fmt.Sprintf("%08x ", crc32.ChecksumIEEE(data))...)
+	line = append(line, data...)
+	return append(line, '\n'), nil
+}
+
+func decodeStoreRecord(line []byte) (storeOp, bool) {
+	var op storeOp
+	line = bytes.TrimRight(line, "\n")
+	if len(line) < 10 || line[8] != ' ' {
+		return op, false
+	}
+	sum, err := strconv.ParseUint(string(line[:8]), 16, 32)
+	if err != nil || uint32(sum) != crc32.ChecksumIEEE(line[9:]) {
+		return op, false
+	}
+	return op, json.Unmarshal(line[9:], &op) == nil
+}
+
+func (s *Store) apply(op storeOp) {
+	switch op.Op {
+	case "put":
+		coll := s.docs[op.Collection]
+		if coll == nil {
+			coll = make(map[string]json.RawMessage)
+			s.docs[op.Collection] = coll
+		}
+		s.unindex(op.Collection, op.ID)
+		coll[op.ID] = op.Doc
+		s.index(op.Collection, op.ID)
+	case "del":
+		s.unindex(op.Collection, op.ID)
+		delete(s.docs[op.Collection], op.ID)
+	case "batch":
+		for _, o := range op.Ops {
+			s.apply(o)
+		}
+	}
+}
+
+func (s *Store) index(coll, id string) {
+	doc := s.docs[coll][id]
+	for _, idx := range s.indexes[coll] {
+		if v, ok := idx.key(doc); ok {
+			if idx.values[v] == nil {
+				idx.values[v] = make(map[string]bool)
+			}
+			idx.values[v][id] = true
+		}
+	}
+}
+
+func (s *Store) unindex(coll, id string) {
+	doc, ok := s.docs[coll][id]
+	if !ok {
+		return
+	}
+	for _, idx := range s.indexes[coll] {
+		if v, ok := idx.key(doc); ok {
+			delete(idx.values[v], id)
+			if len(idx.values[v]) == 0 {
+				delete(idx.values, v)
+			}
+		}
+	}
+}
+
+func (s *Store) write(op storeOp) error {
+	line, err := encodeStoreRecord(op)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return ErrStoreClosed
+	}
+	_, err = s.file.Write(line)
+	if err == nil && s.SyncWrites {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		return s.rollback(err)
+	}
+	s.size += int64(len(line))
+	s.apply(op)
+	s.records++
+	if s.CompactAfter > 0 && s.records > s.CompactAfter && s.records > 2*s.live() {
+		if err := s.compact(); err != nil {
+			log.Printf("[store] compaction failed: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) rollback(err error) error {
+	if terr := s.file.Truncate(s.size); terr != nil {
+		log.Printf("[store] closing store, cannot truncate torn record at offset %d: %v", s.size, terr)
+		s.file.Close()
+		s.file = nil
+		return errors.Join(err, terr)
+	}
+	return err
+}
+
+func (s *Store) live() int {
+	n := 0
+	for _, coll := range s.docs {
+		n += len(coll)
+	}
+	return n
+}
+
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return ErrStoreClosed
+	}
+	return s.compact()
+}
+
+func (s *Store) compact() error {
+	var buf bytes.Buffer
+	colls := make([]string, 0, len(s.docs))
+	for c := range s.docs {
+		colls = append(colls, c)
+	}
+	sort.Strings(colls)
+	n := 0
+	for _, c := range colls {
+		for id, doc := range s.docs[c] {
+			line, err := encodeStoreRecord(storeOp{Op: "put", Collection: c, ID: id, Doc: doc})
+			if err != nil {
+				return err
+			}
+			buf.Write(line)
+			n++
+		}
+	}
+
+	path := filepath.Join(s.dir, storeLogName)
+	f, err := replaceFileAtomic(path, buf.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file, s.size = f, int64(buf.Len())
+	s.records = n
+	return nil
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *Store) Collections() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]string, 0, len(s.docs))
+	for c, docs := range s.docs {
+		if len(docs) > 0 {
+			out = append(out, c)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+type StoreBatch struct {
+	ops []storeOp
+}
+
+func (b *StoreBatch) Put(collection, id string, v interface{}) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b.ops = append(b.ops, storeOp{Op: "put", Collection: collection, ID: id, Doc: doc})
+	return nil
+}
+
+func (b *StoreBatch) Delete(collection, id string) {
+	b.ops = append(b.ops, storeOp{Op: "del", Collection: collection, ID: id})
+}
+
+func (s *Store) Batch(fn func(b *StoreBatch) error) error {
+	b := &StoreBatch{}
+	if err := fn(b); err != nil {
+		return err
+	}
+	if len(b.ops) == 0 {
+		return nil
+	}
+	return s.write(storeOp{Op: "batch", Ops: b.ops})
+}
+
+type Collection[T any] struct {
+	s    *Store
+	name string
+}
+
+func CollectionOf[T any](s *Store, name string) *Collection[T] {
+	return &Collection[T]{s: s, name: name}
+}
+
+func (c *Collection[T]) Index(name string, key func(T) (string, bool)) *Collection[T] {
+	idx := &storeIndex{
+		key: func(raw json.RawMessage) (string, bool) {
+			var v T
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return "", false
+			}
+			return key(v)
+		},
+		values: make(map[string]map[string]bool),
+	}
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	if c.s.indexes[c.name] == nil {
+		c.s.indexes[c.name] = make(map[string]*storeIndex)
+	}
+	c.s.indexes[c.name][name] = idx
+	for id, doc := range c.s.docs[c.name] {
+		if v, ok := idx.key(doc); ok {
+			if idx.values[v] == nil {
+				idx.values[v] = make(map[string]bool)
+			}
+			idx.values[v][id] = true
+		}
+	}
+	return c
+}
+
+func (c *Collection[T]) Get(id string) (T, bool, error) {
+	var v T
+	c.s.mu.RLock()
+	doc, ok := c.s.docs[c.name][id]
+	c.s.mu.RUnlock()
+	if !ok {
+		return v, false, nil
+	}
+	err := json.Unmarshal(doc, &v)
+	return v, err == nil, err
+}
+
+func (c *Collection[T]) Put(id string, v T) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.s.write(storeOp{Op: "put", Collection: c.name, ID: id, Doc: doc})
+}
+
+func (c *Collection[T]) Insert(v T) (string, error) {
+	id := randomID()
+	return id, c.Put(id, v)
+}
+
+func (c *Collection[T]) Delete(id string) error {
+	return c.s.write(storeOp{Op: "del", Collection: c.name, ID: id})
+}
+
+func (c *Collection[T]) Count() int {
+	c.s.mu.RLock()
+	defer c.s.mu.RUnlock()
+	return len(c.s.docs[c.name])
+}
+
+func (c *Collection[T]) IDs() []string {
+	c.s.mu.RLock()
+	ids := make([]string, 0, len(c.s.docs[c.name]))
+	for id := range c.s.docs[c.name] {
+		ids = append(ids, id)
+	}
+	c.s.mu.RUnlock()
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *Collection[T]) All() (map[string]T, error) {
+	return c.Filter(func(string, T) bool { return true })
+}
+
+func (c *Collection[T]) Filter(keep func(id string, v T) bool) (map[string]T, error) {
+	c.s.mu.RLock()
+	docs := make(map[string]json.RawMessage, len(c.s.docs[c.name]))
+	for id, doc := range c.s.docs[c.name] {
+		docs[id] = doc
+	}
+	c.s.mu.RUnlock()
+
+	out := make(map[string]T)
+	for id, doc := range docs {
+		var v T
+		if err := json.Unmarshal(doc, &v); err != nil {
+			return nil, fmt.Errorf("decode %s/%s: %w", c.name, id, err)
+		}
+		if keep(id, v) {
+			out[id] = v
+		}
+	}
+	return out, nil
+}
+
+func (c *Collection[T]) Find(index, value string) (map[string]T, error) {
+	c.s.mu.RLock()
+	idx, ok := c.s.indexes[c.name][index]
+	if !ok {
+		c.s.mu.RUnlock()
+		return nil, fmt.Errorf("collection %s has no index %q", c.name, index)
+	}
+	docs := make(map[string]json.RawMessage, len(idx.values[value]))
+	for id := range idx.values[value] {
+		docs[id] = c.s.docs[c.name][id]
+	}
+	c.s.mu.RUnlock()
+
+	out := make(map[string]T, len(docs))
+	for id, doc := range docs {
+		var v T
+		if err := json.Unmarshal(doc, &v); err != nil {
+			return nil, fmt.Errorf("decode %s/%s: %w", c.name, id, err)
+		}
+		out[id] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,331 @@
+package birdactyl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrChecksumMismatch = errors.New("birdactyl: transfer checksum mismatch")
+
+var TransferChunkSize = 1 << 20
+
+var TransferRetries = 3
+
+func transferRetryable(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func transferBackoff(attempt int) {
+	time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+}
+
+type FileReader struct {
+	api      *API
+	path     string
+	open     func(ctx context.Context, offset int64) (pb.PanelService_DownloadFileClient, error)
+	checksum string
+	stream   pb.PanelService_DownloadFileClient
+	cancel   context.CancelFunc
+	buf      []byte
+	offset   int64
+	size     int64
+	hash     hash.Hash
+	retries  int
+	eof      bool
+	err      error
+}
+
+func (a *API) OpenReader(serverID, path string) (*FileReader, error) {
+	return a.openReader(path, "", func(ctx context.Context, offset int64) (pb.PanelService_DownloadFileClient, error) {
+		return a.panel.DownloadFile(ctx, &pb.DownloadFileRequest{
+			ServerId:  serverID,
+			Path:      path,
+			Offset:    offset,
+			ChunkSize: int32(TransferChunkSize),
+		})
+	})
+}
+
+func (a *API) openReader(name, checksum string, open func(context.Context, int64) (pb.PanelService_DownloadFileClient, error)) (*FileReader, error) {
+	r := &FileReader{api: a, path: name, open: open, checksum: checksum, size: -1, hash: sha256.New()}
+	if err := r.connect(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FileReader) connect() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	ctx, cancel := context.WithCancel(r.api.ctx())
+	stream, err := r.open(ctx, r.offset)
+	if err != nil {
+		cancel()
+		return err
+	}
+	r.stream, r.cancel = stream, cancel
+	return nil
+}
+
+func (r *FileReader) Size() int64 {
+	return r.size
+}
+
+func (r *FileReader) Offset() int64 {
+	return r.offset
+}
+
+func (r *FileReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.eof {
+			return 0, io.EOF
+		}
+		r.err = r.next()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *FileReader) next() error {
+	chunk, err := r.stream.Recv()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		if r.retries >= TransferRetries || !transferRetryable(err) {
+			return fmt.Errorf("download %s at offset %d: %w", r.path, r.offset, err)
+		}
+		r.retries++
+		transferBackoff(r.retries)
+		if err := r.connect(); err != nil {
+			return fmt.Errorf("resume download %s at offset %d: %w", r.path, r.offset, err)
+		}
+		return nil
+	}
+	if chunk.GetOffset() != r.offset {
+		return fmt.Errorf("download %s: expected chunk at offset %d, got %d", r.path, r.offset, chunk.GetOffset())
+	}
+	if chunk.GetTotalSize() > 0 {
+		r.size = chunk.GetTotalSize()
+	}
+	r.hash.Write(chunk.GetData())
+	r.offset += int64(len(chunk.GetData()))
+	r.buf = chunk.GetData()
+	r.retries = 0
+
+	if chunk.GetEof() {
+		r.eof = true
+		if r.size >= 0 && r.offset != r.size {
+			return fmt.Errorf("download %s: received %d of %d bytes", r.path, r.offset, r.size)
+		}
+		sum := hex.EncodeToString(r.hash.Sum(nil))
+		if (chunk.GetSha256() != "" && chunk.GetSha256() != sum) || (r.checksum != "" && r.checksum != sum) {
+			return fmt.Errorf("download %s: %w", r.path, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+func (r *FileReader) Close() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	return nil
+}
+
+func (a *API) Download(serverID, path string, w io.Writer) (int64, error) {
+	r, err := a.OpenReader(serverID, path)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+type FileWriter struct {
+	api      *API
+	serverID string
+	path     string
+	uploadID string
+	stream   pb.PanelService_UploadFileClient
+	cancel   context.CancelFunc
+	buf      []byte
+	offset   int64
+	hash     hash.Hash
+	closed   bool
+	err      error
+}
+
+func (a *API) OpenWriter(serverID, path string) (*FileWriter, error) {
+	return a.openWriter(serverID, path, randomID(), 0, sha256.New())
+}
+
+func (a *API) openWriter(serverID, path, uploadID string, offset int64, h hash.Hash) (*FileWriter, error) {
+	ctx, cancel := context.WithCancel(a.ctx())
+	stream, err := a.panel.UploadFile(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &FileWriter{
+		api:      a,
+		serverID: serverID,
+		path:     path,
+		uploadID: uploadID,
+		stream:   stream,
+		cancel:   cancel,
+		offset:   offset,
+		hash:     h,
+	}, nil
+}
+
+func (w *FileWriter) UploadID() string {
+	return w.uploadID
+}
+
+func (w *FileWriter) Offset() int64 {
+	return w.offset + int64(len(w.buf))
+}
+
+func (w *FileWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf = append(w.buf, p...)
+	w.hash.Write(p)
+	for len(w.buf) >= TransferChunkSize {
+		if err := w.send(w.buf[:TransferChunkSize], false); err != nil {
+			return len(p), err
+		}
+		w.buf = w.buf[TransferChunkSize:]
+	}
+	return len(p), nil
+}
+
+func (w *FileWriter) send(data []byte, eof bool) error {
+	chunk := &pb.FileChunk{
+		ServerId: w.serverID,
+		Path:     w.path,
+		UploadId: w.uploadID,
+		Offset:   w.offset,
+		Data:     data,
+		Eof:      eof,
+	}
+	if eof {
+		chunk.TotalSize = w.offset + int64(len(data))
+		chunk.Sha256 = hex.EncodeToString(w.hash.Sum(nil))
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		if err == io.EOF {
+			_, err = w.stream.CloseAndRecv()
+		}
+		w.err = fmt.Errorf("upload %s at offset %d: %w", w.path, w.offset, err)
+		return w.err
+	}
+	w.offset += int64(len(data))
+	return nil
+}
+
+func (w *FileWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	defer w.cancel()
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.send(w.buf, true); err != nil {
+		return err
+	}
+	w.buf = nil
+	resp, err := w.stream.CloseAndRecv()
+	if err != nil {
+		w.err = fmt.Errorf("upload %s: %w", w.path, err)
+		return w.err
+	}
+	if resp.GetSize() != w.offset {
+		w.err = fmt.Errorf("upload %s: server stored %d of %d bytes", w.path, resp.GetSize(), w.offset)
+	} else if resp.GetSha256() != hex.EncodeToString(w.hash.Sum(nil)) {
+		w.err = fmt.Errorf("upload %s: %w", w.path, ErrChecksumMismatch)
+	}
+	return w.err
+}
+
+func (w *FileWriter) Abort() {
+	if !w.closed {
+		w.closed = true
+		w.cancel()
+	}
+}
+
+func (a *API) Upload(serverID, path string, r io.ReadSeeker) (string, error) {
+	uploadID := randomID()
+	err := a.upload(serverID, path, uploadID, 0, sha256.New(), r)
+	for attempt := 1; err != nil && attempt <= TransferRetries && transferRetryable(err); attempt++ {
+		transferBackoff(attempt)
+		err = a.ResumeUpload(serverID, path, uploadID, r)
+	}
+	return uploadID, err
+}
+
+func (a *API) ResumeUpload(serverID, path, uploadID string, r io.ReadSeeker) error {
+	st, err := a.GetUploadStatus(serverID, uploadID)
+	if err != nil {
+		return err
+	}
+	if st.Complete {
+		return nil
+	}
+	if st.Path != "" && st.Path != path {
+		return fmt.Errorf("upload %s belongs to %s, not %s", uploadID, st.Path, path)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h := sha256.New()
+	if n, err := io.CopyN(h, r, st.Received); err != nil {
+		return fmt.Errorf("resume upload %s: source has %d of %d received bytes: %w", uploadID, n, st.Received, err)
+	}
+	return a.upload(serverID, path, uploadID, st.Received, h, r)
+}
+
+func (a *API) upload(serverID, path, uploadID string, offset int64, h hash.Hash, r io.ReadSeeker) error {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	w, err := a.openWriter(serverID, path, uploadID, offset, h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,275 @@
+package birdactyl
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/pizzlad/birdactyl-go-sdk/proto"
+)
+
+type ConsoleMatch struct {
+	ServerID string
+	Line     ConsoleLine
+	Lines    []ConsoleLine
+	Entry    *LogEntry
+	Groups   []string
+	Named    map[string]string
+}
+
+type ConsoleMatchHandler func(ConsoleMatch)
+
+var ConsoleReplayLimit = 5000
+
+type ConsoleTrigger struct {
+	ServerID string
+	Pattern  string
+	Handler  ConsoleMatchHandler
+	Cooldown time.Duration
+	Lines    int
+	Parser   LogParser
+}
+
+type consoleTrigger struct {
+	ConsoleTrigger
+	re    *regexp.Regexp
+	mu    sync.Mutex
+	state map[string]*triggerState
+}
+
+type triggerState struct {
+	fired  time.Time
+	window []ConsoleLine
+}
+
+type consoleTriggers struct {
+	mu       sync.Mutex
+	triggers []*consoleTrigger
+	subs     map[string]*ConsoleSubscription
+	api      *API
+	async    *AsyncAPI
+	console  *Console
+	refresh  time.Duration
+	stopCh   chan struct{}
+}
+
+func (p *Plugin) OnConsoleMatch(serverID, pattern string, handler ConsoleMatchHandler) *Plugin {
+	return p.OnConsoleTrigger(ConsoleTrigger{ServerID: serverID, Pattern: pattern, Handler: handler})
+}
+
+func (p *Plugin) OnConsoleTrigger(t ConsoleTrigger) *Plugin {
+	if t.ServerID == "" {
+		t.ServerID = "*"
+	}
+	re, err := regexp.Compile(t.Pattern)
+	if err != nil {
+		return p.setupError(fmt.Errorf("console trigger %q: %w", t.Pattern, err))
+	}
+	p.triggers.add(&consoleTrigger{ConsoleTrigger: t, re: re, state: make(map[string]*triggerState)})
+	return p
+}
+
+func (p *Plugin) ReplayConsoleTriggers(serverID string, since time.Time) error {
+	return p.triggers.replay(serverID, since)
+}
+
+func newConsoleTriggers() *consoleTriggers {
+	return &consoleTriggers{subs: make(map[string]*ConsoleSubscription), refresh: time.Minute}
+}
+
+func (e *consoleTriggers) add(t *consoleTrigger) {
+	e.mu.Lock()
+	e.triggers = append(e.triggers, t)
+	started := e.console != nil
+	e.mu.Unlock()
+	if started {
+		e.sync()
+	}
+}
+
+func (e *consoleTriggers) start(api *API, async *AsyncAPI, console *Console) {
+	e.mu.Lock()
+	if e.stopCh != nil {
+		e.mu.Unlock()
+		return
+	}
+	e.api, e.async, e.console = api, async, console
+	e.stopCh = make(chan struct{})
+	stop := e.stopCh
+	e.mu.Unlock()
+	e.sync()
+
+	go func() {
+		ticker := time.NewTicker(e.refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.sync()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (e *consoleTriggers) stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopCh != nil {
+		close(e.stopCh)
+		e.stopCh = nil
+	}
+	for id, sub := range e.subs {
+		sub.Close()
+		delete(e.subs, id)
+	}
+}
+
+func (e *consoleTriggers) sync() {
+	e.mu.Lock()
+	wildcard := false
+	want := make(map[string]bool)
+	for _, t := range e.triggers {
+		if t.ServerID == "*" {
+			wildcard = true
+		} else {
+			want[t.ServerID] = true
+		}
+	}
+	e.mu.Unlock()
+
+	if wildcard {
+		servers, err := e.async.ListServers().Get()
+		if err != nil {
+			log.Printf("[triggers] failed to list servers: %v", err)
+			return
+		}
+		for _, s := range servers {
+			want[s.ID] = true
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopCh == nil {
+		return
+	}
+	for id, sub := range e.subs {
+		if !want[id] {
+			sub.Close()
+			delete(e.subs, id)
+		}
+	}
+	for id := range want {
+		if _, ok := e.subs[id]; !ok {
+			e.subs[id] = e.console.Subscribe(id, e.dispatch)
+		}
+	}
+}
+
+func (e *consoleTriggers) dispatch(line ConsoleLine) {
+	e.mu.Lock()
+	triggers := e.triggers
+	e.mu.Unlock()
+	for _, t := range triggers {
+		if t.ServerID == "*" || t.ServerID == line.ServerID {
+			t.feed(line)
+		}
+	}
+}
+
+func (e *consoleTriggers) replay(serverID string, since time.Time) error {
+	e.mu.Lock()
+	triggers := e.triggers
+	api := e.api
+	e.mu.Unlock()
+
+	var matching []*consoleTrigger
+	for _, t := range triggers {
+		if (t.ServerID == "*" || t.ServerID == serverID) && t.Lines <= 1 {
+			matching = append(matching, t)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	r, err := api.panel.SearchLogs(api.ctx(), &pb.SearchLogsRequest{ServerId: serverID, Pattern: ".*", Regex: true, Limit: int32(ConsoleReplayLimit), Since: consoleMillis(since)})
+	if err != nil {
+		return err
+	}
+	states := make([]triggerState, len(matching))
+	for _, m := range r.GetMatches() {
+		line := ConsoleLine{ServerID: serverID, Line: m.Line, Timestamp: consoleTime(m.Timestamp)}
+		for i, t := range matching {
+			if match, ok := t.evaluate(&states[i], line, line.Timestamp); ok {
+				t.Handler(match)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *consoleTrigger) feed(line ConsoleLine) {
+	t.mu.Lock()
+	st, ok := t.state[line.ServerID]
+	if !ok {
+		st = &triggerState{}
+		t.state[line.ServerID] = st
+	}
+	match, ok := t.evaluate(st, line, time.Now())
+	t.mu.Unlock()
+	if ok {
+		t.Handler(match)
+	}
+}
+
+func (t *consoleTrigger) evaluate(st *triggerState, line ConsoleLine, now time.Time) (ConsoleMatch, bool) {
+	match := ConsoleMatch{ServerID: line.ServerID, Line: line}
+	text := StripANSI(line.Line)
+	if t.Parser != nil {
+		entry, ok := t.Parser.Parse(text)
+		if !ok {
+			return match, false
+		}
+		match.Entry = &entry
+		text = entry.Message
+	}
+
+	if t.Lines > 1 {
+		st.window = append(st.window, ConsoleLine{ServerID: line.ServerID, Line: text, Timestamp: line.Timestamp})
+		if len(st.window) > t.Lines {
+			st.window = st.window[len(st.window)-t.Lines:]
+		}
+		parts := make([]string, len(st.window))
+		for i, l := range st.window {
+			parts[i] = l.Line
+		}
+		text = strings.Join(parts, "\n")
+	}
+
+	groups := t.re.FindStringSubmatch(text)
+	if groups == nil || (t.Cooldown > 0 && now.Sub(st.fired) < t.Cooldown) {
+		return match, false
+	}
+	st.fired = now
+	if t.Lines > 1 {
+		match.Lines = st.window
+		st.window = nil
+	} else {
+		match.Lines = []ConsoleLine{line}
+	}
+
+	match.Groups = groups
+	match.Named = make(map[string]string)
+	for i, name := range t.re.SubexpNames() {
+		if name != "" {
+			match.Named[name] = groups[i]
+		}
+	}
+	return match, true
+}
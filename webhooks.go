@@ -0,0 +1,571 @@
+package birdactyl
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+type WebhookFormat string
+
+const (
+	WebhookJSON    WebhookFormat = "json"
+	WebhookDiscord WebhookFormat = "discord"
+	WebhookSlack   WebhookFormat = "slack"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	WebhookSignatureHeader = "X-Birdactyl-Signature"
+	WebhookTimestampHeader = "X-Birdactyl-Timestamp"
+	WebhookEventHeader     = "X-Birdactyl-Event"
+	WebhookDeliveryHeader  = "X-Birdactyl-Delivery"
+)
+
+type WebhookEndpoint struct {
+	ID          string
+	URL         string
+	Events      []string
+	Secret      string
+	Format      WebhookFormat
+	Template    string
+	Headers     map[string]string
+	MaxAttempts int
+}
+
+type WebhookDelivery struct {
+	ID          string
+	Endpoint    string
+	Event       Event
+	State       string
+	Attempts    int
+	Status      int
+	LastError   string
+	CreatedAt   time.Time
+	NextAttempt time.Time
+	DeliveredAt time.Time
+}
+
+type WebhookOptions struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	Concurrency int
+	LogSize     int
+	Timeout     time.Duration
+}
+
+var WebhookEventTypes = []string{
+	"server.create", "server.update", "server.delete", "server.start", "server.stop", "server.restart", "server.kill",
+	"server.status", "server.suspend", "server.unsuspend", "server.reinstall", "server.transfer",
+	"user.create", "user.update", "user.delete", "user.ban", "user.unban",
+	"database.create", "database.delete",
+	"backup.create", "backup.delete", "backup.restore",
+	"subuser.add", "subuser.update", "subuser.remove",
+	"node.create", "node.update", "node.delete",
+	"package.create", "package.update", "package.delete",
+}
+
+var DefaultWebhookOptions = WebhookOptions{MaxAttempts: 8, MinBackoff: 5 * time.Second, MaxBackoff: 10 * time.Minute, Concurrency: 4, LogSize: 500, Timeout: 30 * time.Second}
+
+type WebhookDispatcher struct {
+	mu        sync.Mutex
+	persistMu sync.Mutex
+	opts      WebhookOptions
+	bus       *eventBus
+	api       *API
+	dir       string
+	endpoints map[string]*webhookEndpoint
+	queue     []*WebhookDelivery
+	log       []WebhookDelivery
+	wake      chan struct{}
+	started   bool
+	stopCh    chan struct{}
+	done      chan struct{}
+	dirty     bool
+}
+
+type webhookEndpoint struct {
+	WebhookEndpoint
+	tmpl *template.Template
+}
+
+type webhookState struct {
+	Queue []*WebhookDelivery
+	Log   []WebhookDelivery
+}
+
+func newWebhookDispatcher(bus *eventBus) *WebhookDispatcher {
+	return &WebhookDispatcher{opts: DefaultWebhookOptions, bus: bus, endpoints: make(map[string]*webhookEndpoint), wake: make(chan struct{}, 1)}
+}
+
+func (p *Plugin) ForwardEvents(ep WebhookEndpoint) *Plugin {
+	if err := p.webhooks.Add(ep); err != nil {
+		return p.setupError(fmt.Errorf("webhook %s: %w", ep.URL, err))
+	}
+	return p
+}
+
+func (p *Plugin) UseWebhookOptions(opts WebhookOptions) *Plugin {
+	p.webhooks.SetOptions(opts)
+	return p
+}
+
+func (p *Plugin) Webhooks() *WebhookDispatcher {
+	return p.webhooks
+}
+
+func (d *WebhookDispatcher) SetOptions(opts WebhookOptions) {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultWebhookOptions.MaxAttempts
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultWebhookOptions.MinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultWebhookOptions.Concurrency
+	}
+	if opts.LogSize <= 0 {
+		opts.LogSize = DefaultWebhookOptions.LogSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWebhookOptions.Timeout
+	}
+	d.mu.Lock()
+	d.opts = opts
+	d.mu.Unlock()
+}
+
+func (d *WebhookDispatcher) Add(ep WebhookEndpoint) error {
+	if ep.URL == "" {
+		return fmt.Errorf("webhook endpoint has no URL")
+	}
+	if ep.ID == "" {
+		ep.ID = ep.URL
+	}
+	if ep.Format == "" {
+		ep.Format = WebhookJSON
+	}
+	e := &webhookEndpoint{WebhookEndpoint: ep}
+	if ep.Template != "" {
+		t, err := template.New(ep.ID).Funcs(template.FuncMap{"json": webhookJSON}).Parse(ep.Template)
+		if err != nil {
+			return err
+		}
+		e.tmpl = t
+	}
+	types, err := d.expand(ep.Events)
+	if err != nil {
+		return err
+	}
+	d.bus.declare(types...)
+	d.mu.Lock()
+	d.endpoints[ep.ID] = e
+	d.mu.Unlock()
+	d.launch()
+	return nil
+}
+
+func (d *WebhookDispatcher) expand(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"*"}
+	}
+	known := append(append([]string(nil), WebhookEventTypes...), d.bus.types()...)
+	var out []string
+	for _, p := range patterns {
+		if !strings.Contains(p, "*") {
+			out = append(out, p)
+			continue
+		}
+		if strings.Index(p, "*") != len(p)-1 {
+			return nil, fmt.Errorf("event pattern %q: only a trailing * is supported", p)
+		}
+		matched := false
+		for _, t := range known {
+			if webhookMatches([]string{p}, t) {
+				out = append(out, t)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("event pattern %q matches no known event type", p)
+		}
+	}
+	return out, nil
+}
+
+func (d *WebhookDispatcher) Remove(id string) {
+	d.mu.Lock()
+	delete(d.endpoints, id)
+	d.mu.Unlock()
+}
+
+func (d *WebhookDispatcher) Dispatch(ev Event) []string {
+	d.mu.Lock()
+	var ids []string
+	for _, ep := range d.endpoints {
+		if webhookMatches(ep.Events, ev.Type) {
+			ids = append(ids, d.enqueue(ep.ID, ev))
+		}
+	}
+	d.mu.Unlock()
+	if len(ids) > 0 {
+		d.notify()
+	}
+	return ids
+}
+
+func (d *WebhookDispatcher) Send(endpointID string, ev Event) (string, error) {
+	d.mu.Lock()
+	if _, ok := d.endpoints[endpointID]; !ok {
+		d.mu.Unlock()
+		return "", fmt.Errorf("webhook endpoint %q not registered", endpointID)
+	}
+	id := d.enqueue(endpointID, ev)
+	d.mu.Unlock()
+	d.notify()
+	return id, nil
+}
+
+func (d *WebhookDispatcher) Pending() []WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]WebhookDelivery, len(d.queue))
+	for i, del := range d.queue {
+		out[i] = *del
+	}
+	return out
+}
+
+func (d *WebhookDispatcher) Deliveries() []WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]WebhookDelivery(nil), d.log...)
+}
+
+func (d *WebhookDispatcher) enqueue(endpointID string, ev Event) string {
+	now := time.Now()
+	del := &WebhookDelivery{ID: randomID(), Endpoint: endpointID, Event: ev, State: DeliveryPending, CreatedAt: now, NextAttempt: now}
+	d.queue = append(d.queue, del)
+	d.dirty = true
+	return del.ID
+}
+
+func (d *WebhookDispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *WebhookDispatcher) start(api *API, dir string) {
+	d.mu.Lock()
+	if d.started {
+		d.mu.Unlock()
+		return
+	}
+	d.started, d.api, d.dir = true, api, dir
+	d.mu.Unlock()
+	d.launch()
+}
+
+func (d *WebhookDispatcher) launch() {
+	d.mu.Lock()
+	if !d.started || d.stopCh != nil || len(d.endpoints) == 0 {
+		d.mu.Unlock()
+		return
+	}
+	d.stopCh, d.done = make(chan struct{}), make(chan struct{})
+	stop, done := d.stopCh, d.done
+	d.mu.Unlock()
+
+	d.load()
+	unsubscribe := d.bus.subscribe("*", func(ev Event) { d.Dispatch(ev) })
+
+	go func() {
+		defer close(done)
+		defer unsubscribe()
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			d.deliverDue()
+			d.flush()
+			select {
+			case <-ticker.C:
+			case <-d.wake:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) stop() {
+	d.mu.Lock()
+	d.started = false
+	stop, done := d.stopCh, d.done
+	d.stopCh, d.done = nil, nil
+	d.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	d.flush()
+}
+
+func (d *WebhookDispatcher) deliverDue() {
+	now := time.Now()
+	d.mu.Lock()
+	var due []*WebhookDelivery
+	for _, del := range d.queue {
+		if !del.NextAttempt.After(now) {
+			due = append(due, del)
+		}
+	}
+	concurrency := d.opts.Concurrency
+	d.mu.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	ForEach(context.Background(), due, concurrency, func(ctx context.Context, del *WebhookDelivery) error {
+		d.attempt(del)
+		return nil
+	})
+}
+
+func (d *WebhookDispatcher) attempt(del *WebhookDelivery) {
+	d.mu.Lock()
+	ep, ok := d.endpoints[del.Endpoint]
+	api, opts := d.api, d.opts
+	d.mu.Unlock()
+
+	var status int
+	var err error
+	if !ok {
+		err = fmt.Errorf("webhook endpoint %q not registered", del.Endpoint)
+	} else if api == nil {
+		err = fmt.Errorf("webhook dispatcher not started")
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		status, err = ep.deliver(api.WithContext(ctx), del)
+		cancel()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dirty = true
+	del.Attempts++
+	del.Status = status
+	maxAttempts := opts.MaxAttempts
+	if ok && ep.MaxAttempts > 0 {
+		maxAttempts = ep.MaxAttempts
+	}
+	switch {
+	case err == nil:
+		del.State, del.LastError, del.DeliveredAt = DeliveryDelivered, "", time.Now()
+	case !ok || del.Attempts >= maxAttempts:
+		del.State, del.LastError = DeliveryFailed, err.Error()
+		log.Printf("[webhooks] giving up on delivery %s to %s after %d attempts: %v", del.ID, del.Endpoint, del.Attempts, err)
+	default:
+		del.LastError = err.Error()
+		backoff := opts.MinBackoff << (del.Attempts - 1)
+		if backoff > opts.MaxBackoff || backoff <= 0 {
+			backoff = opts.MaxBackoff
+		}
+		del.NextAttempt = time.Now().Add(backoff + time.Duration(mrand.Int63n(int64(backoff)/2+1)))
+		return
+	}
+
+	for i, q := range d.queue {
+		if q == del {
+			d.queue = append(d.queue[:i], d.queue[i+1:]...)
+			break
+		}
+	}
+	d.log = append(d.log, *del)
+	if len(d.log) > opts.LogSize {
+		d.log = d.log[len(d.log)-opts.LogSize:]
+	}
+}
+
+func (ep *webhookEndpoint) deliver(api *API, del *WebhookDelivery) (int, error) {
+	body, err := ep.payload(del)
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now()
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		WebhookEventHeader:     del.Event.Type,
+		WebhookDeliveryHeader:  del.ID,
+		WebhookTimestampHeader: strconv.FormatInt(ts.Unix(), 10),
+	}
+	if ep.Secret != "" {
+		headers[WebhookSignatureHeader] = SignWebhook(ep.Secret, ts, body)
+	}
+	for k, v := range ep.Headers {
+		headers[k] = v
+	}
+
+	resp := api.HTTPPost(ep.URL, headers, body)
+	if resp.Error != "" {
+		return resp.Status, fmt.Errorf("%s", resp.Error)
+	}
+	if resp.Status < 200 || resp.Status >= 300 {
+		return resp.Status, fmt.Errorf("status %d", resp.Status)
+	}
+	return resp.Status, nil
+}
+
+func (ep *webhookEndpoint) payload(del *WebhookDelivery) ([]byte, error) {
+	ev := del.Event
+	if ep.tmpl != nil {
+		var buf bytes.Buffer
+		err := ep.tmpl.Execute(&buf, map[string]interface{}{"Type": ev.Type, "Data": ev.Data, "Event": ev, "Delivery": del.ID, "Time": del.CreatedAt})
+		return buf.Bytes(), err
+	}
+
+	keys := make([]string, 0, len(ev.Data))
+	for k := range ev.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	switch ep.Format {
+	case WebhookDiscord:
+		fields := make([]map[string]interface{}, 0, len(keys))
+		for _, k := range keys {
+			fields = append(fields, map[string]interface{}{"name": k, "value": ev.Data[k], "inline": true})
+		}
+		return json.Marshal(map[string]interface{}{
+			"embeds": []map[string]interface{}{{
+				"title":     ev.Type,
+				"fields":    fields,
+				"timestamp": del.CreatedAt.UTC().Format(time.RFC3339),
+			}},
+		})
+	case WebhookSlack:
+		lines := []string{"*" + ev.Type + "*"}
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", k, ev.Data[k]))
+		}
+		return json.Marshal(map[string]interface{}{"text": strings.Join(lines, "\n")})
+	}
+	return json.Marshal(map[string]interface{}{
+		"id":        del.ID,
+		"event":     ev.Type,
+		"data":      ev.Data,
+		"sync":      ev.Sync,
+		"timestamp": del.CreatedAt.Unix(),
+	})
+}
+
+func SignWebhook(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookMatches(patterns []string, eventType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if p == "*" || p == eventType {
+			return true
+		}
+		if strings.HasSuffix(p, "*") && strings.HasPrefix(eventType, p[:len(p)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func webhookJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (d *WebhookDispatcher) load() {
+	if d.dir == "" {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(d.dir, "deliveries.json"))
+	if err != nil {
+		return
+	}
+	var st webhookState
+	if err := json.Unmarshal(data, &st); err != nil {
+		log.Printf("[webhooks] discarding unreadable queue: %v", err)
+		return
+	}
+	d.mu.Lock()
+	d.queue = append(st.Queue, d.queue...)
+	d.log = append(st.Log, d.log...)
+	d.mu.Unlock()
+}
+
+func (d *WebhookDispatcher) flush() {
+	d.persistMu.Lock()
+	defer d.persistMu.Unlock()
+	d.mu.Lock()
+	if d.dir == "" || !d.dirty {
+		d.mu.Unlock()
+		return
+	}
+	d.dirty = false
+	st := webhookState{Queue: make([]*WebhookDelivery, len(d.queue)), Log: d.log}
+	for i, del := range d.queue {
+		c := *del
+		st.Queue[i] = &c
+	}
+	data, err := json.Marshal(st)
+	dir := d.dir
+	d.mu.Unlock()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		err = fmt.Errorf("create %s: %w", dir, err)
+	} else {
+		err = writeFileAtomic(filepath.Join(dir, "deliveries.json"), data, 0644)
+	}
+	if err != nil {
+		log.Printf("[webhooks] failed to persist queue: %v", err)
+		d.mu.Lock()
+		d.dirty = true
+		d.mu.Unlock()
+	}
+}